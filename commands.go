@@ -0,0 +1,501 @@
+package main
+
+import (
+	"GentleWanderings/lib"
+	"GentleWanderings/lib/command"
+	"GentleWanderings/lib/content"
+	"GentleWanderings/lib/printer"
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errQuit ends the session.
+var errQuit = errors.New("quit")
+
+// session is one sitting at the terminal: the journey and everything the
+// commands need to play it.
+type session struct {
+	game        *lib.Game
+	pack        *content.Pack // the content the journey is told with
+	view        lib.View
+	scanner     *bufio.Scanner
+	slots       *lib.Slots
+	reflections bool
+	commands    *command.Registry
+}
+
+// newCommands registers every command the player can type.
+func (s *session) newCommands() *command.Registry {
+	r := command.NewRegistry(os.Stdout)
+	r.Add(&command.Command{
+		Name:    "go",
+		Aliases: []string{"explore", "walk"},
+		Args: []command.Arg{
+			{Name: "direction", Kind: command.Direction},
+			{Name: "path", Kind: command.Number, Optional: true},
+		},
+		Summary: "Wander north, south, east or west",
+		Help: "Heading somewhere new reveals three paths to choose from; name one to\n" +
+			"skip the question, e.g. \"go north 2\" or \"explore east 1\". Heading\n" +
+			"somewhere you've already been walks you back there. Typing just the\n" +
+			"number of a direction works too.",
+		Run: func(args command.Args) error {
+			dir, err := direction(args["direction"])
+			if err != nil {
+				return err
+			}
+			return s.walk(dir, args.Int("path"))
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "map",
+		Aliases: []string{"m"},
+		Args:    []command.Arg{{Name: "n|s|e|w|fit|here", Kind: command.Word, Optional: true}},
+		Summary: "View the map around you",
+		Help: "map n/s/e/w scrolls half a screen that way, map fit zooms out until\n" +
+			"the whole world fits, and map here scrolls back to you.",
+		Run: func(args command.Args) error {
+			if err := mapCommand(s.game, strings.ToLower(args["n|s|e|w|fit|here"])); err != nil {
+				return err
+			}
+			s.view.ShowMap(s.game)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "places",
+		Aliases: []string{"detailed"},
+		Summary: "List every place you've found",
+		Run: func(command.Args) error {
+			s.view.ShowDetailedMap(s.game)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "look",
+		Aliases: []string{"l", "here"},
+		Summary: "Look around where you are",
+		Run: func(command.Args) error {
+			s.view.ShowCurrentLocation(s.game)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "inventory",
+		Aliases: []string{"i", "inv"},
+		Summary: "See what you've collected",
+		Run: func(command.Args) error {
+			s.view.ShowInventory(s.game)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "journal",
+		Aliases: []string{"j"},
+		Summary: "Read your journey log",
+		Run: func(command.Args) error {
+			s.view.ShowJournal(s.game)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "write",
+		Aliases: []string{"w"},
+		Args:    []command.Arg{{Name: "text", Kind: command.Text, Optional: true}},
+		Summary: "Write in your journal",
+		Help: "write on its own opens your journal until you enter an empty line;\n" +
+			"write followed by text adds that line straight away.",
+		Run: func(args command.Args) error {
+			text := args["text"]
+			if text == "" {
+				fmt.Println("\n✍️  Write in your journal. Finish with an empty line.")
+				text = readEntry(s.scanner)
+			}
+			if err := s.game.Write(text, ""); err != nil {
+				fmt.Println("You close your journal without writing.")
+				return nil
+			}
+			fmt.Printf("Your words are added to Day %d.\n", s.game.TurnCount)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "travel",
+		Args:    []command.Arg{{Name: "place", Kind: command.Text}},
+		Summary: "Fast travel to a place you know",
+		Help: "Name the place, or give its coordinates like \"travel 2,-1\". You walk\n" +
+			"the shortest way through places you've explored, a day for each step.",
+		Run: func(args command.Args) error {
+			return s.travel(args["place"])
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "undo",
+		Summary: "Take back the last place you explored",
+		Help: "Undo returns you to just before you last explored somewhere new: the\n" +
+			"place, what you found there and what you wrote since are taken back,\n" +
+			"and the paths ahead will be the same as before. Undo again to go\n" +
+			"further back, as far as the --undo flag allows.",
+		Run: func(command.Args) error {
+			if err := s.game.Undo(); err != nil {
+				return err
+			}
+			tile := s.game.CurrentTile()
+			fmt.Printf("You retrace your steps. It is Day %d again.\n", s.game.TurnCount)
+			fmt.Printf("\n🌿 %s\n%s\n", tile.Theme, tile.Description)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "redo",
+		Summary: "Explore again what you last undid",
+		Run: func(command.Args) error {
+			if err := s.game.Redo(); err != nil {
+				return err
+			}
+			tile := s.game.CurrentTile()
+			fmt.Printf("You set off once more. It is Day %d.\n", s.game.TurnCount)
+			fmt.Printf("\n🌿 %s\n%s\n", tile.Theme, tile.Description)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name: "export",
+		Args: []command.Arg{
+			{Name: "what", Kind: command.Word, Choices: []string{"journal", "map"}},
+			{Name: "options", Kind: command.Text, Optional: true},
+		},
+		Summary: "Write your journal or map to a file",
+		Help: "export journal [--format md|html|txt] [file] writes your journal, items\n" +
+			"and a map snapshot. export map [file.png|file.svg] draws the map.",
+		Run: func(args command.Args) error {
+			return exportCommand(s.game, append([]string{args["what"]}, strings.Fields(args["options"])...))
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "stats",
+		Aliases: []string{"statistics"},
+		Summary: "See how far you've come",
+		Run: func(command.Args) error {
+			s.view.ShowStatistics(s.game)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "save",
+		Args:    []command.Arg{{Name: "file", Kind: command.Word, Optional: true}},
+		Summary: "Save your journey (to " + lib.DefaultSaveFile + " unless you name a file)",
+		Run: func(args command.Args) error {
+			path := args["file"]
+			if path == "" {
+				path = lib.DefaultSaveFile
+			}
+			if err := s.game.SaveFile(path); err != nil {
+				fmt.Printf("Could not save your journey: %v\n", err)
+				return nil
+			}
+			fmt.Printf("Your journey has been saved to %s.\n", path)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "load",
+		Args:    []command.Arg{{Name: "file", Kind: command.Word, Optional: true}},
+		Summary: "Restore a saved journey",
+		Run: func(args command.Args) error {
+			path := args["file"]
+			if path == "" {
+				path = lib.DefaultSaveFile
+			}
+			loaded, err := lib.LoadGameFile(path)
+			if err != nil {
+				fmt.Printf("Could not load a journey: %v\n", err)
+				return nil
+			}
+			s.game.Replace(loaded)
+			tile := s.game.CurrentTile()
+			fmt.Printf("Your journey from %s has been restored. It is Day %d.\n", path, s.game.TurnCount)
+			fmt.Printf("\n🌿 %s\n%s\n", tile.Theme, tile.Description)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name: "replay",
+		Args: []command.Arg{
+			{Name: "file", Kind: command.Word, Optional: true},
+			{Name: "seconds", Kind: command.Number, Optional: true},
+		},
+		Summary: "Watch a saved journey unfold again, step by step",
+		Help: "Replays the journey saved in the file (" + lib.DefaultSaveFile + " unless you name one)\n" +
+			"from its seed, waiting the given number of seconds between steps (1\n" +
+			"unless you say), then checks the replay ends where the save does.\n" +
+			"Your own journey carries on afterwards as it was.",
+		Run: func(args command.Args) error {
+			path := args["file"]
+			if path == "" {
+				path = lib.DefaultSaveFile
+			}
+			delay := time.Second
+			if args["seconds"] != "" {
+				delay = time.Duration(args.Int("seconds")) * time.Second
+			}
+			return s.replay(path, delay)
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "slots",
+		Summary: "Save, load and tidy your named save slots",
+		Run: func(command.Args) error {
+			s.view.ShowSlots(s.game, s.slots)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "menu",
+		Summary: "Open the menu",
+		Run: func(command.Args) error {
+			s.view.ShowMenu(r.Run)
+			return nil
+		},
+	})
+	r.Add(&command.Command{
+		Name:    "quit",
+		Aliases: []string{"q", "exit"},
+		Summary: "End your session",
+		Run: func(command.Args) error {
+			return errQuit
+		},
+	})
+	return r
+}
+
+// direction finds a lib direction by name, suggesting one for a typo.
+func direction(name string) (lib.Direction, error) {
+	dir, err := lib.DirectionNamed(name)
+	if err != nil {
+		return lib.Direction{}, &command.DirectionError{Input: name, Suggestion: command.SuggestDirection(name)}
+	}
+	return dir, nil
+}
+
+// walk heads one step in dir: back onto a known place, or somewhere new
+// along one of three paths. A path of 0 asks which one to take.
+func (s *session) walk(dir lib.Direction, path int) error {
+	game := s.game
+	if game.GetTile(game.CurrentX+dir.DX, game.CurrentY+dir.DY) != nil {
+		if err := game.Move(dir); err != nil {
+			return err
+		}
+		tile := game.CurrentTile()
+		showPlace(tile)
+		fmt.Printf("\n%s\n", game.LastEntry())
+		return nil
+	}
+
+	// Generate 3 location options
+	options := game.GenerateLocationOptions(game.CurrentX+dir.DX, game.CurrentY+dir.DY)
+	if path == 0 {
+		fmt.Printf("\n✨ As you head %s, three paths reveal themselves:\n\n", dir.Name)
+		for i, opt := range options {
+			fmt.Printf("%d. %s\n   %s\n\n", i+1, opt.Theme, opt.Description)
+		}
+
+		fmt.Print("Which path calls to you? (1-3): ")
+		if !s.scanner.Scan() {
+			return nil
+		}
+		path, _ = strconv.Atoi(strings.TrimSpace(s.scanner.Text()))
+	}
+	if path < 1 || path > len(options) {
+		fmt.Println("Let's try that again...")
+		return nil
+	}
+
+	foundItem, err := game.Explore(dir, options[path-1])
+	if err != nil {
+		return err
+	}
+
+	newTile := game.CurrentTile()
+	showPlace(newTile)
+	fmt.Printf("\n%s\n", newTile.Discovery)
+
+	if foundItem != nil {
+		showFoundItem(foundItem)
+	}
+
+	if prompt := game.DrawPrompt(); prompt != nil {
+		if !s.answer(prompt) {
+			return nil
+		}
+	}
+
+	if s.reflections {
+		if question := game.Reflection(); question != "" {
+			fmt.Println(strings.Repeat("─", 60))
+			fmt.Printf("\n📝 %s\n", question)
+			fmt.Println("   Write your thoughts (finish with an empty line), or just press Enter to walk on.")
+			if err := game.Write(readEntry(s.scanner), question); err == nil {
+				fmt.Println("Your thoughts are tucked into your journal.")
+			}
+		}
+	}
+	return nil
+}
+
+// answer shows a prompt card and asks until it is answered. It reports
+// false if the input ran out first.
+func (s *session) answer(prompt *lib.Prompt) bool {
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("\n🔮 %s\n\n", prompt.Text)
+	for i, choice := range prompt.Choices {
+		fmt.Printf("  %d. %s\n", i+1, choice)
+	}
+
+	for {
+		fmt.Printf("\nWhat do you do? (1-%d): ", len(prompt.Choices))
+		if !s.scanner.Scan() {
+			return false
+		}
+		answer, err := strconv.Atoi(strings.TrimSpace(s.scanner.Text()))
+		if err != nil {
+			fmt.Println("Let's try that again...")
+			continue
+		}
+		text, item, err := s.game.ResolvePrompt(prompt, answer-1)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("\n%s\n", text)
+		if item != nil {
+			showFoundItem(item)
+		}
+		return true
+	}
+}
+
+// travel fast travels to a place named by theme or "x,y" coordinates, after
+// showing the route and asking to confirm.
+func (s *session) travel(place string) error {
+	game := s.game
+	var dest *lib.Tile
+	if xText, yText, ok := strings.Cut(place, ","); ok {
+		x, errX := strconv.Atoi(strings.TrimSpace(xText))
+		y, errY := strconv.Atoi(strings.TrimSpace(yText))
+		if errX != nil || errY != nil {
+			fmt.Println("Travel to a place by name, or by coordinates like: travel 2,-1")
+			return nil
+		}
+		dest = game.GetTile(x, y)
+	} else {
+		dest = game.FindTileByTheme(place)
+	}
+	if dest == nil {
+		fmt.Printf("You don't know of anywhere called %q.\n", place)
+		return nil
+	}
+
+	route := game.FindPath(dest.X, dest.Y)
+	if route == nil {
+		fmt.Printf("You know of no path to %s.\n", dest.Theme)
+		return nil
+	}
+	if len(route) == 1 {
+		fmt.Printf("You are already at %s.\n", dest.Theme)
+		return nil
+	}
+
+	s.view.ShowRoute(game, route)
+	fmt.Printf("The way to %s will take %d days. Set off? (y/n): ", dest.Theme, len(route)-1)
+	if !s.scanner.Scan() || strings.ToLower(strings.TrimSpace(s.scanner.Text())) != "y" {
+		fmt.Println("You stay where you are, for now.")
+		return nil
+	}
+	if err := game.Travel(route); err != nil {
+		return err
+	}
+
+	showPlace(dest)
+	fmt.Printf("\n%s\n", game.LastEntry())
+	return nil
+}
+
+// showPlace prints the name and description of a place just reached.
+func showPlace(tile *lib.Tile) {
+	fmt.Println()
+	fmt.Println("╔════════════════════════════════════════════════════════════╗")
+	fmt.Println("║" + printer.CenterText(tile.Theme, 60) + "║")
+	fmt.Println("╚════════════════════════════════════════════════════════════╝")
+	fmt.Printf("\n%s\n", tile.Description)
+}
+
+// replay plays back the journey saved at path from its action log, printing
+// what each step added to the journal with delay between steps.
+func (s *session) replay(path string, delay time.Duration) error {
+	saved, err := lib.LoadGameFile(path)
+	if err != nil {
+		fmt.Printf("Could not load a journey: %v\n", err)
+		return nil
+	}
+	log := saved.Log()
+	if log == nil {
+		fmt.Printf("The journey in %s was begun before journeys were logged, so it can't be replayed.\n", path)
+		return nil
+	}
+
+	fmt.Printf("\n🎞️  Replaying %s: %d steps from seed %d\n", path, len(log.Actions), log.Seed)
+	written := 0
+	replayed, err := lib.Replay(log, s.pack, func(i int, a lib.Action, g *lib.Game) error {
+		entries := g.JournalLog[written:]
+		written = len(g.JournalLog)
+		if i == 0 && len(entries) > 0 {
+			// The journal opens with where the journey began
+			fmt.Println(entries[0])
+			entries = entries[1:]
+		}
+		if a.Kind == lib.ActionDraw && len(entries) == 0 {
+			return nil // no card turned up
+		}
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		fmt.Printf("\n[%d/%d] %s\n", i+1, len(log.Actions), a)
+		for _, entry := range entries {
+			fmt.Println(entry)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("\nThe replay went astray: %v\n", err)
+		return nil
+	}
+
+	if diffs := replayDiffers(saved, replayed); len(diffs) > 0 {
+		fmt.Printf("\nThe replay ends somewhere other than the save: %s.\n", strings.Join(diffs, "; "))
+		return nil
+	}
+	fmt.Printf("\nThe replay ends exactly where the save does, on Day %d.\n", replayed.TurnCount)
+	return nil
+}
+
+// replayDiffers lists how a replayed journey differs from the saved one.
+func replayDiffers(saved, replayed *lib.Game) []string {
+	diffs := []string{}
+	differ := func(what string, want, got any) {
+		if fmt.Sprint(want) != fmt.Sprint(got) {
+			diffs = append(diffs, fmt.Sprintf("%s is %v, not %v", what, got, want))
+		}
+	}
+	differ("the day", saved.TurnCount, replayed.TurnCount)
+	differ("where you stand", fmt.Sprintf("(%d, %d)", saved.CurrentX, saved.CurrentY), fmt.Sprintf("(%d, %d)", replayed.CurrentX, replayed.CurrentY))
+	differ("the places found", len(saved.Map), len(replayed.Map))
+	differ("the items found", len(saved.Inventory), len(replayed.Inventory))
+	differ("the journal entries", len(saved.JournalLog), len(replayed.JournalLog))
+	return diffs
+}
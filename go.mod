@@ -2,14 +2,19 @@ module GentleWanderings
 
 go 1.24.12
 
-require github.com/hajimehoshi/ebiten/v2 v2.8.7
+require (
+	github.com/hajimehoshi/ebiten/v2 v2.8.7
+	golang.org/x/image v0.27.0
+	golang.org/x/term v0.24.0
+)
 
 require (
 	github.com/ebitengine/gomobile v0.0.0-20240911145611-4856209ac325 // indirect
 	github.com/ebitengine/hideconsole v1.0.0 // indirect
 	github.com/ebitengine/purego v0.8.0 // indirect
+	github.com/go-text/typesetting v0.2.0 // indirect
 	github.com/jezek/xgb v1.1.1 // indirect
-	golang.org/x/image v0.27.0 // indirect
-	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/sync v0.14.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
+	golang.org/x/text v0.25.0 // indirect
 )
@@ -0,0 +1,160 @@
+package guitest
+
+import (
+	"GentleWanderings/gui_game/screens"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tolerance is how far apart two colour channels may be and still match a
+// golden image, allowing for small differences between graphics drivers
+var Tolerance uint8 = 2
+
+// UpdateGolden makes Golden write the images it is given instead of
+// comparing against them. GOLDEN_UPDATE=1 in the environment turns it on.
+var UpdateGolden = os.Getenv("GOLDEN_UPDATE") != ""
+
+// Draw draws the screens offscreen at the size they laid themselves out
+// at, as ebiten would draw the window.
+func (h *Harness) Draw() *ebiten.Image {
+	w, hgt := h.Manager.Layout(h.Width, h.Height)
+	screen := ebiten.NewImage(w, hgt)
+	h.Manager.Draw(screen)
+	return screen
+}
+
+// Pixels draws the screens and reads back what was drawn. It must be called
+// from a test run by Main.
+func (h *Harness) Pixels() *image.RGBA {
+	screen := h.Draw()
+	defer screen.Deallocate()
+	img := image.NewRGBA(screen.Bounds())
+	screen.ReadPixels(img.Pix)
+	return img
+}
+
+// Golden compares what the screens draw with the PNG at path. With
+// UpdateGolden set the drawing is written there instead. On a mismatch the
+// drawing is saved beside the golden image with .got.png on the end to look
+// at. It must be called from a test run by Main.
+func (h *Harness) Golden(path string) error {
+	got := h.Pixels()
+	if UpdateGolden {
+		return writePNG(path, got)
+	}
+
+	want, err := readPNG(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.Join(fmt.Errorf("%s is missing; run with GOLDEN_UPDATE=1 to write it", path), writePNG(gotPath(path), got))
+	}
+	if err != nil {
+		return err
+	}
+	if !want.Bounds().Eq(got.Bounds()) {
+		return errors.Join(fmt.Errorf("%s: drew %v, want %v", path, got.Bounds().Size(), want.Bounds().Size()), writePNG(gotPath(path), got))
+	}
+	differ := 0
+	b := got.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !near(got.RGBAAt(x, y), color.RGBAModel.Convert(want.At(x, y)).(color.RGBA)) {
+				differ++
+			}
+		}
+	}
+	if differ > 0 {
+		return errors.Join(fmt.Errorf("%s: %d pixels differ", path, differ), writePNG(gotPath(path), got))
+	}
+	return nil
+}
+
+// Main runs a package's tests while ebiten's game loop runs, so they can
+// read pixels back, and returns their exit code. Call it from TestMain: the
+// loop has to run on the main thread. It opens a window, so it needs a
+// display, or a virtual one such as xvfb-run.
+func Main(m *testing.M) int {
+	game := &testsGame{run: m.Run, done: make(chan struct{})}
+	if err := ebiten.RunGameWithOptions(game, &ebiten.RunGameOptions{InitUnfocused: true}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	return game.code
+}
+
+// testsGame is the ebiten.Game Main plays: it starts the tests on its first
+// frame and ends once they have finished.
+type testsGame struct {
+	run     func() int
+	code    int
+	started bool
+	done    chan struct{}
+}
+
+func (g *testsGame) Update() error {
+	if !g.started {
+		g.started = true
+		go func() {
+			g.code = g.run()
+			close(g.done)
+		}()
+	}
+	select {
+	case <-g.done:
+		return ebiten.Termination
+	default:
+		return nil
+	}
+}
+
+func (g *testsGame) Draw(*ebiten.Image) {}
+
+func (g *testsGame) Layout(int, int) (int, int) {
+	return screens.ScreenWidth, screens.ScreenHeight
+}
+
+func near(a, b color.RGBA) bool {
+	diff := func(x, y uint8) uint8 {
+		if x > y {
+			return x - y
+		}
+		return y - x
+	}
+	return diff(a.R, b.R) <= Tolerance && diff(a.G, b.G) <= Tolerance && diff(a.B, b.B) <= Tolerance && diff(a.A, b.A) <= Tolerance
+}
+
+func gotPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".got.png"
+}
+
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
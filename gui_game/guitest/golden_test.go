@@ -0,0 +1,32 @@
+//go:build golden
+
+package guitest_test
+
+import (
+	"GentleWanderings/gui_game/guitest"
+	"os"
+	"testing"
+)
+
+// Reading pixels back needs ebiten's game loop, so these tests run inside it
+func TestMain(m *testing.M) {
+	os.Exit(guitest.Main(m))
+}
+
+func TestGoldenMainMenu(t *testing.T) {
+	h := guitest.New(t.TempDir(), 42)
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Golden("testdata/main_menu.png"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGoldenHeadNorth(t *testing.T) {
+	h := guitest.New(t.TempDir(), 42)
+	headNorth(t, h)
+	if err := h.Golden("testdata/north.png"); err != nil {
+		t.Error(err)
+	}
+}
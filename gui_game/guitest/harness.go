@@ -0,0 +1,189 @@
+// Package guitest drives the GUI without a window: it plays back clicks and
+// key presses frame by frame through a screens.Manager, so tests can check
+// the journey it plays and compare what it draws with golden images.
+//
+// Everything but reading back pixels works without a display. ebiten only
+// hands over pixels while its game loop runs, so Golden and Pixels must be
+// called from tests run by Main, which needs a display or a virtual one such
+// as xvfb-run. This package's own golden tests are built with -tags golden.
+package guitest
+
+import (
+	"GentleWanderings/gui_game/screens"
+	"GentleWanderings/gui_game/sprites"
+	"GentleWanderings/lib"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxSettle is how many frames Settle waits for a fade to finish
+const maxSettle = 120
+
+// Harness runs a screens.Manager with scripted input.
+type Harness struct {
+	Manager *screens.Manager
+	Input   *Input
+	Width   int // the size of the outside window the screens are laid out in
+	Height  int
+	Frames  int  // how many frames have been played
+	Quit    bool // a screen asked the game to end
+}
+
+// New creates a harness on the main menu, saving to saveDir. Journeys begun
+// with New are generated from seed, so they play the same every time.
+func New(saveDir string, seed int64) *Harness {
+	// A broken atlas still draws, with placeholders
+	atlas, _ := sprites.Load("")
+	m := screens.NewManager(screens.NewMainMenu(), lib.NewSlots(saveDir), atlas)
+	m.Fade = false
+	m.NewJourney = func() *lib.Game { return lib.NewGameWithSeed(seed) }
+
+	h := &Harness{Manager: m, Input: newInput(), Width: screens.ScreenWidth, Height: screens.ScreenHeight}
+	screens.SetInput(h.Input)
+	return h
+}
+
+// Step plays one frame: the screens are laid out and updated with whatever
+// input was queued, which is then used up.
+func (h *Harness) Step() error {
+	if h.Quit {
+		return errors.New("the game has ended")
+	}
+	h.Manager.Layout(h.Width, h.Height)
+	err := h.Manager.Update()
+	h.Input.endFrame()
+	h.Frames++
+	if errors.Is(err, ebiten.Termination) {
+		h.Quit = true
+		return nil
+	}
+	return err
+}
+
+// Steps plays n frames.
+func (h *Harness) Steps(n int) error {
+	for range n {
+		if err := h.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Settle plays frames until any fade between screens has finished.
+func (h *Harness) Settle() error {
+	for i := 0; h.Manager.Fading(); i++ {
+		if i == maxSettle {
+			return errors.New("the screens never stopped fading")
+		}
+		if err := h.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MoveMouse puts the cursor at x, y on the screen.
+func (h *Harness) MoveMouse(x, y int) {
+	h.Input.x, h.Input.y = x, y
+}
+
+// Click presses and releases the left mouse button at x, y, taking two
+// frames, then lets any fade it started finish.
+func (h *Harness) Click(x, y int) error {
+	h.MoveMouse(x, y)
+	h.Input.down = true
+	if err := h.Step(); err != nil {
+		return err
+	}
+	h.Input.up = true
+	if err := h.Step(); err != nil {
+		return err
+	}
+	return h.Settle()
+}
+
+// Drag holds the left mouse button down from x0, y0 to x1, y1, moving a few
+// pixels a frame.
+func (h *Harness) Drag(x0, y0, x1, y1 int) error {
+	h.MoveMouse(x0, y0)
+	h.Input.down = true
+	if err := h.Step(); err != nil {
+		return err
+	}
+	steps := max(abs(x1-x0), abs(y1-y0))/4 + 1
+	for i := 1; i <= steps; i++ {
+		h.MoveMouse(x0+(x1-x0)*i/steps, y0+(y1-y0)*i/steps)
+		if err := h.Step(); err != nil {
+			return err
+		}
+	}
+	h.Input.up = true
+	return h.Step()
+}
+
+// ClickButton clicks the middle of the button on the top screen whose label
+// starts with label, such as "New" or "2." for the second card.
+func (h *Harness) ClickButton(label string) error {
+	labels := []string{}
+	for _, b := range screens.ButtonsOf(h.Manager.Top()) {
+		if strings.HasPrefix(b.Label, label) {
+			return h.Click(b.Rect.X+b.Rect.W/2, b.Rect.Y+b.Rect.H/2)
+		}
+		labels = append(labels, b.Label)
+	}
+	return fmt.Errorf("no button %q on the screen, only %q", label, labels)
+}
+
+// Press taps key for one frame, then lets any fade it started finish.
+func (h *Harness) Press(key ebiten.Key) error {
+	h.Input.pressed[key] = true
+	if err := h.Step(); err != nil {
+		return err
+	}
+	return h.Settle()
+}
+
+// Hold keeps key down until Release, as for Shift.
+func (h *Harness) Hold(key ebiten.Key) {
+	h.Input.held[key] = true
+}
+
+// Release lets go of a key held with Hold.
+func (h *Harness) Release(key ebiten.Key) {
+	delete(h.Input.held, key)
+}
+
+// Type types text in a single frame.
+func (h *Harness) Type(text string) error {
+	h.Input.chars = []rune(text)
+	return h.Step()
+}
+
+// Scroll turns the mouse wheel at x, y by notches, up if positive.
+func (h *Harness) Scroll(x, y int, notches float64) error {
+	h.MoveMouse(x, y)
+	h.Input.wheel = notches
+	return h.Step()
+}
+
+// Journey returns the journey being played, if one has been started or
+// loaded.
+func (h *Harness) Journey() *lib.Game {
+	return h.Manager.Journey()
+}
+
+// Top returns the screen being shown.
+func (h *Harness) Top() screens.Screen {
+	return h.Manager.Top()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,149 @@
+package guitest_test
+
+import (
+	"GentleWanderings/gui_game/guitest"
+	"GentleWanderings/gui_game/screens"
+	"GentleWanderings/lib"
+	"fmt"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// headNorth starts a journey from seed 42 and takes the first path north.
+func headNorth(t *testing.T, h *guitest.Harness) {
+	t.Helper()
+	if err := h.ClickButton("New"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Press(ebiten.KeyArrowUp); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.ClickButton("1."); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewHeadNorth(t *testing.T) {
+	h := guitest.New(t.TempDir(), 42)
+	headNorth(t, h)
+
+	g := h.Journey()
+	if g == nil {
+		t.Fatal("no journey was started")
+	}
+	if g.Seed() != 42 {
+		t.Errorf("journey has seed %d, want 42", g.Seed())
+	}
+	if g.CurrentX != 0 || g.CurrentY != 1 || g.TurnCount != 2 {
+		t.Errorf("at (%d, %d) on day %d, want (0, 1) on day 2", g.CurrentX, g.CurrentY, g.TurnCount)
+	}
+
+	// The first card is the first path the world offers there
+	want := lib.NewGameWithSeed(42).GenerateLocationOptions(0, 1)[0]
+	if tile := g.CurrentTile(); tile.Theme != want.Theme || tile.Description != want.Description {
+		t.Errorf("arrived at %q, want %q", tile.Theme, want.Theme)
+	}
+	if entry := g.JournalLog[1]; entry.Kind != lib.EntryArrival || entry.X != 0 || entry.Y != 1 {
+		t.Errorf("journal goes on with %+v, want the arrival at (0, 1)", entry)
+	}
+}
+
+func TestEscapeTurnsBackFromPaths(t *testing.T) {
+	h := guitest.New(t.TempDir(), 42)
+	if err := h.ClickButton("New"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Press(ebiten.KeyArrowUp); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Press(ebiten.KeyEscape); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.ClickButton("1."); err == nil {
+		t.Error("the paths are still offered after Esc")
+	}
+	if g := h.Journey(); g.CurrentX != 0 || g.CurrentY != 0 || g.TurnCount != 1 {
+		t.Errorf("at (%d, %d) on day %d, want to have stayed at (0, 0) on day 1", g.CurrentX, g.CurrentY, g.TurnCount)
+	}
+}
+
+func TestEscapeKeepsPrompt(t *testing.T) {
+	// Seed 2 finds an old shelter on the first path north
+	h := guitest.New(t.TempDir(), 2)
+	headNorth(t, h)
+	g := h.Journey()
+	if g.PendingPrompt() == nil {
+		t.Fatal("no prompt was drawn")
+	}
+
+	if err := h.Press(ebiten.KeyEscape); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := h.Top().(*screens.MenuOverlay); !ok {
+		t.Fatalf("Esc showed %T, want the pause menu", h.Top())
+	}
+	if err := h.ClickButton("Resume"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.ClickButton("1. Search the ruins"); err != nil {
+		t.Fatalf("the prompt went away: %v", err)
+	}
+	if g.PendingPrompt() != nil {
+		t.Error("the prompt is still waiting after it was answered")
+	}
+}
+
+// checkButtons fails unless every button on the top screen lies inside the
+// w by h screen without covering another.
+func checkButtons(t *testing.T, h *guitest.Harness, w, hgt int) {
+	t.Helper()
+	buttons := screens.ButtonsOf(h.Top())
+	for i, b := range buttons {
+		r := b.Rect
+		if r.W <= 0 || r.H <= 0 || r.X < 0 || r.Y < 0 || r.X+r.W > w || r.Y+r.H > hgt {
+			t.Errorf("%q at %+v is off a %dx%d screen", b.Label, r, w, hgt)
+		}
+		for _, other := range buttons[i+1:] {
+			o := other.Rect
+			if r.X < o.X+o.W && o.X < r.X+r.W && r.Y < o.Y+o.H && o.Y < r.Y+r.H {
+				t.Errorf("%q at %+v covers %q at %+v", b.Label, r, other.Label, o)
+			}
+		}
+	}
+}
+
+func TestScreenLayout(t *testing.T) {
+	h := guitest.New(t.TempDir(), 42)
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	checkButtons(t, h, screens.ScreenWidth, screens.ScreenHeight)
+
+	if err := h.ClickButton("New"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Press(ebiten.KeyArrowUp); err != nil {
+		t.Fatal(err)
+	}
+
+	// The cards show the three paths the world offers, in order
+	options := lib.NewGameWithSeed(42).GenerateLocationOptions(0, 1)
+	cards := screens.ButtonsOf(h.Top())
+	if len(cards) != len(options) {
+		t.Fatalf("%d cards, want %d", len(cards), len(options))
+	}
+	for i, card := range cards {
+		if want := fmt.Sprintf("%d. %s", i+1, options[i].Theme); card.Label != want || card.Text != options[i].Description {
+			t.Errorf("card %d is %q: %q, want %q: %q", i+1, card.Label, card.Text, want, options[i].Description)
+		}
+	}
+	checkButtons(t, h, screens.ScreenWidth, screens.ScreenHeight)
+
+	// A bigger window lays the journey out afresh
+	h.Width, h.Height = 1024, 768
+	if err := h.Step(); err != nil {
+		t.Fatal(err)
+	}
+	checkButtons(t, h, 1024, 768)
+}
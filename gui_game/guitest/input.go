@@ -0,0 +1,55 @@
+package guitest
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Input is a keyboard and mouse played back by a Harness. Events queued on
+// it last one frame, as they would from ebiten, except keys held down and
+// the cursor, which stay until changed.
+type Input struct {
+	x, y     int
+	wheel    float64
+	held     map[ebiten.Key]bool
+	pressed  map[ebiten.Key]bool
+	down, up bool // the left mouse button went down or came up this frame
+	chars    []rune
+}
+
+func newInput() *Input {
+	return &Input{held: map[ebiten.Key]bool{}, pressed: map[ebiten.Key]bool{}}
+}
+
+// endFrame forgets the events of the frame just played.
+func (in *Input) endFrame() {
+	in.wheel = 0
+	in.pressed = map[ebiten.Key]bool{}
+	in.down, in.up = false, false
+	in.chars = nil
+}
+
+func (in *Input) CursorPosition() (int, int) {
+	return in.x, in.y
+}
+
+func (in *Input) Wheel() (float64, float64) {
+	return 0, in.wheel
+}
+
+func (in *Input) IsKeyPressed(key ebiten.Key) bool {
+	return in.held[key] || in.pressed[key]
+}
+
+func (in *Input) IsKeyJustPressed(key ebiten.Key) bool {
+	return in.pressed[key]
+}
+
+func (in *Input) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return button == ebiten.MouseButtonLeft && in.down
+}
+
+func (in *Input) IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	return button == ebiten.MouseButtonLeft && in.up
+}
+
+func (in *Input) AppendInputChars(runes []rune) []rune {
+	return append(runes, in.chars...)
+}
@@ -0,0 +1,15 @@
+package screens
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Background is the colour behind every screen
+var Background = color.RGBA{24, 28, 36, 255}
+
+// DrawBackground clears the screen to the background colour
+func DrawBackground(screen *ebiten.Image) {
+	screen.Fill(Background)
+}
@@ -0,0 +1,142 @@
+package screens
+
+import (
+	"GentleWanderings/gui_game/types"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Button is a labelled button. It lightens under the mouse or keyboard
+// focus, darkens while held, and clicks when the mouse is released over it.
+// A button with Text is a card: the label is a heading over wrapped text.
+type Button struct {
+	Label  string
+	Text   string
+	Rect   types.Rect
+	Colour color.RGBA
+	action func(m *Manager) error
+
+	hovered bool
+	pressed bool // the mouse went down on the button and hasn't come up yet
+}
+
+func NewButton(label string, x, y, w, h int, clr color.RGBA, action func(m *Manager) error) *Button {
+	return &Button{
+		Label:  label,
+		Rect:   types.Rect{X: x, Y: y, W: w, H: h},
+		Colour: clr,
+		action: action,
+	}
+}
+
+// update tracks the mouse and reports whether the button was clicked: the
+// mouse went down on it and came back up still over it.
+func (b *Button) update() bool {
+	mx, my := input.CursorPosition()
+	b.hovered = b.Rect.Contains(mx, my)
+	if input.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && b.hovered {
+		b.pressed = true
+	}
+	if input.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		clicked := b.pressed && b.hovered
+		b.pressed = false
+		return clicked
+	}
+	return false
+}
+
+func (b *Button) Draw(screen *ebiten.Image, focused bool) {
+	fill := b.Colour
+	switch {
+	case b.pressed && b.hovered:
+		fill = shade(fill, 0.7)
+	case b.hovered || focused:
+		fill = shade(fill, 1.3)
+	}
+	r := b.Rect
+	vector.DrawFilledRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), fill, false)
+	if focused {
+		vector.StrokeRect(screen, float32(r.X)+1, float32(r.Y)+1, float32(r.W)-2, float32(r.H)-2, 2, TextColour, false)
+	}
+
+	if b.Text == "" {
+		// A plain button has its label in the middle
+		x := r.X + (r.W-TextWidth(Body, b.Label))/2
+		y := r.Y + (r.H-LineHeight(Body))/2
+		DrawText(screen, b.Label, Body, x, y, TextColour)
+		return
+	}
+	DrawText(screen, b.Label, Body, r.X+8, r.Y+6, TextColour)
+	DrawWrapped(screen, b.Text, Body, r.X+8, r.Y+6+LineHeight(Body), r.W-16, r.Y+r.H, DimColour)
+}
+
+// shade scales a colour's brightness by f.
+func shade(c color.RGBA, f float64) color.RGBA {
+	scale := func(v uint8) uint8 {
+		return uint8(min(float64(v)*f, 255))
+	}
+	return color.RGBA{scale(c.R), scale(c.G), scale(c.B), c.A}
+}
+
+// Buttons are the buttons of one screen. Tab and Shift-Tab move the
+// keyboard focus between them, and Enter or Space presses the focused one.
+type Buttons struct {
+	List   []*Button
+	Arrows bool // the arrow keys move the focus too
+	focus  int  // index of the focused button, or -1
+}
+
+func NewButtons(arrows bool, buttons ...*Button) *Buttons {
+	return &Buttons{List: buttons, Arrows: arrows, focus: -1}
+}
+
+// Focused returns the button with the keyboard focus, if any.
+func (bs *Buttons) Focused() *Button {
+	if bs.focus < 0 || bs.focus >= len(bs.List) {
+		return nil
+	}
+	return bs.List[bs.focus]
+}
+
+// Update handles the mouse and keyboard for every button, running the
+// action of any that is clicked.
+func (bs *Buttons) Update(m *Manager) error {
+	if len(bs.List) == 0 {
+		return nil
+	}
+
+	shift := input.IsKeyPressed(ebiten.KeyShift)
+	back := input.IsKeyJustPressed(ebiten.KeyTab) && shift
+	forward := input.IsKeyJustPressed(ebiten.KeyTab) && !shift
+	if bs.Arrows {
+		back = back || input.IsKeyJustPressed(ebiten.KeyArrowUp) || input.IsKeyJustPressed(ebiten.KeyArrowLeft)
+		forward = forward || input.IsKeyJustPressed(ebiten.KeyArrowDown) || input.IsKeyJustPressed(ebiten.KeyArrowRight)
+	}
+	switch {
+	case back && bs.focus < 0:
+		bs.focus = len(bs.List) - 1
+	case back:
+		bs.focus = (bs.focus + len(bs.List) - 1) % len(bs.List)
+	case forward:
+		bs.focus = (bs.focus + 1) % len(bs.List)
+	}
+
+	if b := bs.Focused(); b != nil && (input.IsKeyJustPressed(ebiten.KeyEnter) || input.IsKeyJustPressed(ebiten.KeySpace)) {
+		return b.action(m)
+	}
+	for i, b := range bs.List {
+		if b.update() {
+			bs.focus = i
+			return b.action(m)
+		}
+	}
+	return nil
+}
+
+func (bs *Buttons) Draw(screen *ebiten.Image) {
+	for i, b := range bs.List {
+		b.Draw(screen, i == bs.focus)
+	}
+}
@@ -0,0 +1,158 @@
+package screens
+
+import (
+	"GentleWanderings/gui_game/types"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// How the camera moves and zooms
+const (
+	cameraEase  = 0.15 // the share of the way to its target the camera moves each frame
+	zoomStep    = 1.25 // how much one notch of the wheel zooms
+	minZoom     = 12   // pixels per tile
+	maxZoom     = 64
+	dragSlop    = 4 // pixels the mouse can move before a click becomes a drag
+	defaultZoom = cellSize
+)
+
+// Camera looks at the world through a rectangle of the screen. It eases
+// towards whatever it follows, zooms with the mouse wheel and pans when the
+// map is dragged, never straying past the explored land.
+type Camera struct {
+	View types.Rect // where on the screen the world is drawn
+	X, Y float64    // the tile at the centre of the view; north is up
+	Zoom float64    // pixels per tile
+
+	targetX, targetY float64
+	minX, maxX       float64 // the bounds the centre is kept within
+	minY, maxY       float64
+
+	pressed      bool // the mouse went down in the view
+	dragging     bool // and has moved far enough to pan
+	downX, downY int  // where it went down
+	lastX, lastY int  // where it was last frame
+}
+
+// NewCamera creates a camera looking through view at tile 0, 0. Bound it
+// before moving it.
+func NewCamera(view types.Rect) *Camera {
+	return &Camera{View: view, Zoom: defaultZoom}
+}
+
+// Follow starts the camera easing towards tile x, y.
+func (c *Camera) Follow(x, y int) {
+	c.targetX, c.targetY = c.clamp(float64(x), float64(y))
+}
+
+// Jump centres the camera on tile x, y at once.
+func (c *Camera) Jump(x, y int) {
+	c.Follow(x, y)
+	c.X, c.Y = c.targetX, c.targetY
+}
+
+// Bound keeps the centre of the view within the tiles from minX, minY to
+// maxX, maxY.
+func (c *Camera) Bound(minX, maxX, minY, maxY int) {
+	c.minX, c.maxX = float64(minX), float64(maxX)
+	c.minY, c.maxY = float64(minY), float64(maxY)
+	c.X, c.Y = c.clamp(c.X, c.Y)
+	c.targetX, c.targetY = c.clamp(c.targetX, c.targetY)
+}
+
+func (c *Camera) clamp(x, y float64) (float64, float64) {
+	return max(min(x, c.maxX), c.minX), max(min(y, c.maxY), c.minY)
+}
+
+// Update eases the camera along and handles the wheel and dragging. It
+// reports the tile clicked on, if the mouse was released over the view
+// without dragging.
+func (c *Camera) Update() (x, y int, clicked bool) {
+	mx, my := input.CursorPosition()
+	inView := c.View.Contains(mx, my)
+
+	if _, wheel := input.Wheel(); wheel != 0 && inView {
+		c.ZoomAt(mx, my, math.Pow(zoomStep, wheel))
+	}
+
+	switch {
+	case input.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && inView:
+		c.pressed, c.dragging = true, false
+		c.downX, c.downY = mx, my
+	case c.pressed && input.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
+		c.pressed = false
+		if !c.dragging && inView {
+			x, y = c.ToTile(mx, my)
+			clicked = true
+		}
+	case c.pressed:
+		if abs(mx-c.downX) > dragSlop || abs(my-c.downY) > dragSlop {
+			c.dragging = true
+		}
+		if c.dragging {
+			// The world moves with the mouse, so the camera moves against it
+			c.X, c.Y = c.clamp(c.X-float64(mx-c.lastX)/c.Zoom, c.Y+float64(my-c.lastY)/c.Zoom)
+			c.targetX, c.targetY = c.X, c.Y
+		}
+	}
+	c.lastX, c.lastY = mx, my
+	c.ease()
+	return x, y, clicked
+}
+
+// ease moves the camera a step closer to its target.
+func (c *Camera) ease() {
+	c.X += (c.targetX - c.X) * cameraEase
+	c.Y += (c.targetY - c.Y) * cameraEase
+	if math.Abs(c.targetX-c.X) < 0.01 && math.Abs(c.targetY-c.Y) < 0.01 {
+		c.X, c.Y = c.targetX, c.targetY
+	}
+}
+
+// ZoomAt zooms by factor, keeping the tile under screen point sx, sy where
+// it is.
+func (c *Camera) ZoomAt(sx, sy int, factor float64) {
+	zoom := max(min(c.Zoom*factor, maxZoom), minZoom)
+	cx, cy := c.centre()
+	dx, dy := float64(sx)-cx, float64(sy)-cy
+	c.X += dx/c.Zoom - dx/zoom
+	c.Y -= dy/c.Zoom - dy/zoom
+	c.Zoom = zoom
+	c.X, c.Y = c.clamp(c.X, c.Y)
+	c.targetX, c.targetY = c.X, c.Y
+}
+
+// centre is the middle of the view on the screen.
+func (c *Camera) centre() (float64, float64) {
+	return float64(c.View.X) + float64(c.View.W)/2, float64(c.View.Y) + float64(c.View.H)/2
+}
+
+// ToScreen converts a point in tile coordinates to the screen. Tile x, y
+// covers x-0.5 to x+0.5 across and y-0.5 to y+0.5 down.
+func (c *Camera) ToScreen(x, y float64) (sx, sy float64) {
+	cx, cy := c.centre()
+	return cx + (x-c.X)*c.Zoom, cy - (y-c.Y)*c.Zoom
+}
+
+// ToTile returns the tile under screen point sx, sy.
+func (c *Camera) ToTile(sx, sy int) (x, y int) {
+	cx, cy := c.centre()
+	return int(math.Floor(c.X + (float64(sx)-cx)/c.Zoom + 0.5)), int(math.Floor(c.Y - (float64(sy)-cy)/c.Zoom + 0.5))
+}
+
+// TileRect is the square of the screen tile x, y is drawn in. Neighbouring
+// squares meet exactly, whatever the zoom.
+func (c *Camera) TileRect(x, y int) types.Rect {
+	left, top := c.ToScreen(float64(x)-0.5, float64(y)+0.5)
+	right, bottom := c.ToScreen(float64(x)+0.5, float64(y)-0.5)
+	l, t := int(math.Floor(left)), int(math.Floor(top))
+	return types.Rect{X: l, Y: t, W: int(math.Floor(right)) - l, H: int(math.Floor(bottom)) - t}
+}
+
+// Visible returns the tiles at least partly in view.
+func (c *Camera) Visible() (minX, maxX, minY, maxY int) {
+	minX, maxY = c.ToTile(c.View.X, c.View.Y)
+	maxX, minY = c.ToTile(c.View.X+c.View.W-1, c.View.Y+c.View.H-1)
+	return minX, maxX, minY, maxY
+}
@@ -0,0 +1,374 @@
+package screens
+
+import (
+	"GentleWanderings/gui_game/sprites"
+	"GentleWanderings/gui_game/types"
+	"GentleWanderings/lib"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Layout of the gameplay screen, in pixels. The map grows with the window
+// and the panel keeps to its right edge.
+const (
+	mapX, mapY     = 16, 40
+	mapBottom      = 80 // room under the map for the hint line
+	cellSize       = 24 // pixels per tile before any zooming
+	panelW         = 232
+	portraitH      = 48
+	lineH          = 16
+	cardH, cardGap = 96, 8
+)
+
+// padAt lays out the direction buttons as a compass, N above S with W and E
+// either side, from the panel's left edge and the bottom of the screen
+var padAt = []types.Rect{
+	{X: 96, Y: 120, W: 40, H: 26},
+	{X: 96, Y: 60, W: 40, H: 26},
+	{X: 144, Y: 90, W: 40, H: 26},
+	{X: 48, Y: 90, W: 40, H: 26},
+}
+
+var (
+	itemColour   = color.RGBA{230, 190, 60, 255}
+	selectColour = color.RGBA{250, 240, 200, 255}
+	cardColour   = color.RGBA{56, 62, 80, 245}
+	buttonColour = color.RGBA{0, 110, 90, 255}
+)
+
+// padKeys walk the same way as the direction buttons, in lib.Directions order
+var padKeys = []ebiten.Key{ebiten.KeyArrowUp, ebiten.KeyArrowDown, ebiten.KeyArrowRight, ebiten.KeyArrowLeft}
+
+// overlayKeys open an overlay over the journey
+var overlayKeys = []struct {
+	key  ebiten.Key
+	open func(s *GamePlay) Screen
+}{
+	{ebiten.KeyI, func(s *GamePlay) Screen { return NewInventory(s.Journey) }},
+	{ebiten.KeyJ, func(s *GamePlay) Screen { return NewJournal(s.Journey) }},
+	{ebiten.KeyM, func(s *GamePlay) Screen { return NewMapOverlay(s.Journey, s.atlas) }},
+	{ebiten.KeyEscape, func(s *GamePlay) Screen { return NewPause(s.Journey) }},
+}
+
+// GamePlay is the screen where the journey is played. It hosts a lib.Game
+// and draws the explored map, where the player stands and what they found.
+type GamePlay struct {
+	Journey *lib.Game
+	atlas   *sprites.Atlas
+	camera  *Camera
+
+	width, height int     // the size the screen is laid out for
+	panelX        int     // the left edge of the panel
+	atX, atY      int     // where the camera last saw the player
+	selected      *[2]int // the tile last clicked on the map, or nil
+
+	pad      *Buttons         // the direction buttons
+	cards    *Buttons         // the choices laid over the map, or nil
+	heading  string           // what the cards are choosing between
+	onChoose func(choice int) // runs when a card is picked
+	answer   bool             // the cards answer a prompt, so Esc cannot turn them away
+	message  []string         // the discovery, items found and prompt outcomes of the last step
+}
+
+// NewGamePlay creates the gameplay screen for a journey, drawn with the
+// sprites in atlas.
+func NewGamePlay(journey *lib.Game, atlas *sprites.Atlas) *GamePlay {
+	s := &GamePlay{Journey: journey, atlas: atlas, atX: journey.CurrentX, atY: journey.CurrentY}
+	if tile := journey.CurrentTile(); tile != nil {
+		s.message = []string{tile.Discovery}
+	}
+	s.camera = NewCamera(types.Rect{})
+	s.camera.Bound(journey.Bounds())
+	s.camera.Jump(journey.CurrentX, journey.CurrentY)
+	s.pad = NewButtons(false)
+	for _, dir := range lib.Directions {
+		s.pad.List = append(s.pad.List, NewButton(dir.Name[:1], 0, 0, 0, 0, buttonColour, func(*Manager) error {
+			s.walk(dir)
+			return nil
+		}))
+	}
+	s.arrange(ScreenWidth, ScreenHeight)
+
+	// A journey saved with a card still to answer picks up there
+	if prompt := journey.PendingPrompt(); prompt != nil {
+		s.offer(prompt)
+	}
+	return s
+}
+
+// Layout lays the screen out to fill the window, down to ScreenWidth x
+// ScreenHeight; a smaller window is scaled down.
+func (s *GamePlay) Layout(outsideWidth, outsideHeight int) (int, int) {
+	s.arrange(max(outsideWidth, ScreenWidth), max(outsideHeight, ScreenHeight))
+	return s.width, s.height
+}
+
+// arrange places the map, the panel and the buttons for a screen of w x h.
+func (s *GamePlay) arrange(w, h int) {
+	if w == s.width && h == s.height {
+		return
+	}
+	s.width, s.height = w, h
+	s.panelX = w - mapX - panelW
+	s.camera.View = types.Rect{X: mapX, Y: mapY, W: s.panelX - 2*mapX, H: h - mapY - mapBottom}
+	for i, b := range s.pad.List {
+		b.Rect = types.Rect{X: s.panelX + padAt[i].X, Y: h - padAt[i].Y, W: padAt[i].W, H: padAt[i].H}
+	}
+	if s.cards != nil {
+		for i, c := range s.cards.List {
+			c.Rect = s.cardRect(i)
+		}
+	}
+}
+
+// Update handles one frame of input.
+func (s *GamePlay) Update(m *Manager) error {
+	// The camera keeps to the explored land and follows the player
+	g := s.Journey
+	s.camera.Bound(g.Bounds())
+	if g.CurrentX != s.atX || g.CurrentY != s.atY {
+		s.atX, s.atY = g.CurrentX, g.CurrentY
+		s.camera.Follow(g.CurrentX, g.CurrentY)
+	}
+
+	if s.cards != nil {
+		s.camera.ease()
+		if input.IsKeyJustPressed(ebiten.KeyEscape) {
+			// A prompt stays until it is answered, but the journey can
+			// still be paused and saved with it pending
+			if s.answer {
+				m.Push(NewPause(s.Journey))
+				return nil
+			}
+			s.cards = nil
+			return nil
+		}
+		for i := range s.cards.List {
+			if input.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+				s.choose(i)
+				return nil
+			}
+		}
+		return s.cards.Update(m)
+	}
+
+	for _, overlay := range overlayKeys {
+		if input.IsKeyJustPressed(overlay.key) {
+			m.Push(overlay.open(s))
+			return nil
+		}
+	}
+	for i, key := range padKeys {
+		if input.IsKeyJustPressed(key) {
+			s.walk(lib.Directions[i])
+			return nil
+		}
+	}
+	if input.IsKeyJustPressed(ebiten.KeyC) {
+		s.camera.Follow(g.CurrentX, g.CurrentY)
+	}
+	if x, y, clicked := s.camera.Update(); clicked {
+		s.click(x, y)
+		return nil
+	}
+	return s.pad.Update(m)
+}
+
+// clickable is the cards while there is a choice to make, or the direction
+// buttons.
+func (s *GamePlay) clickable() []*Button {
+	if s.cards != nil {
+		return s.cards.List
+	}
+	return s.pad.List
+}
+
+// click selects the tile at x, y, and walks there if it is next to the
+// player.
+func (s *GamePlay) click(x, y int) {
+	s.selected = &[2]int{x, y}
+	for _, dir := range lib.Directions {
+		if s.Journey.CurrentX+dir.DX == x && s.Journey.CurrentY+dir.DY == y {
+			s.walk(dir)
+			return
+		}
+	}
+}
+
+// showCards lays choices over the map as cards, calling onChoose with the
+// one picked.
+func (s *GamePlay) showCards(heading string, titles, texts []string, onChoose func(choice int)) {
+	s.heading = heading
+	s.onChoose = onChoose
+	s.answer = false
+	s.cards = NewButtons(true)
+	for i, title := range titles {
+		r := s.cardRect(i)
+		c := NewButton(fmt.Sprintf("%d. %s", i+1, title), r.X, r.Y, r.W, r.H, cardColour, func(*Manager) error {
+			s.choose(i)
+			return nil
+		})
+		if i < len(texts) {
+			c.Text = texts[i]
+		}
+		s.cards.List = append(s.cards.List, c)
+	}
+	s.cards.focus = 0
+}
+
+func (s *GamePlay) choose(choice int) {
+	onChoose := s.onChoose
+	s.cards, s.onChoose = nil, nil
+	onChoose(choice)
+}
+
+// walk heads one step in dir: back onto a known place, or somewhere new
+// once one of three paths has been picked from the cards.
+func (s *GamePlay) walk(dir lib.Direction) {
+	g := s.Journey
+	x, y := g.CurrentX+dir.DX, g.CurrentY+dir.DY
+	if g.GetTile(x, y) != nil {
+		if err := g.Move(dir); err != nil {
+			s.message = []string{err.Error()}
+			return
+		}
+		s.message = []string{g.LastEntry().Text}
+		return
+	}
+
+	options := g.GenerateLocationOptions(x, y)
+	titles, texts := []string{}, []string{}
+	for _, opt := range options {
+		titles = append(titles, opt.Theme)
+		texts = append(texts, opt.Description)
+	}
+	s.showCards(fmt.Sprintf("As you head %s, three paths reveal themselves:", dir.Name), titles, texts, func(choice int) {
+		s.explore(dir, options[choice])
+	})
+}
+
+func (s *GamePlay) explore(dir lib.Direction, option lib.LocationOption) {
+	g := s.Journey
+	item, err := g.Explore(dir, option)
+	if err != nil {
+		s.message = []string{err.Error()}
+		return
+	}
+	s.message = []string{g.CurrentTile().Discovery}
+	if item != nil {
+		s.message = append(s.message, "You found something: "+item.Name+"!")
+	}
+
+	if prompt := g.DrawPrompt(); prompt != nil {
+		s.offer(prompt)
+	}
+}
+
+// offer shows a prompt card's answers as cards to choose from.
+func (s *GamePlay) offer(prompt *lib.Prompt) {
+	g := s.Journey
+	s.showCards(prompt.Text, prompt.Choices, nil, func(choice int) {
+		text, item, err := g.ResolvePrompt(prompt, choice)
+		if err != nil {
+			s.message = append(s.message, err.Error())
+			return
+		}
+		s.message = append(s.message, text)
+		if item != nil {
+			s.message = append(s.message, "You found something: "+item.Name+"!")
+		}
+	})
+	s.answer = true
+}
+
+// Draw draws the map, the side panel and any cards waiting for a choice.
+func (s *GamePlay) Draw(screen *ebiten.Image) {
+	// An overlay can draw this screen at its own size
+	s.arrange(screen.Bounds().Dx(), screen.Bounds().Dy())
+	DrawBackground(screen)
+	g := s.Journey
+	tile := g.CurrentTile()
+	DrawText(screen, fmt.Sprintf("Day %d - %s", g.TurnCount, tile.Theme), Title, mapX, 10, TextColour)
+
+	s.drawMap(screen)
+	s.drawPanel(screen, tile)
+
+	hint := s.height - 26
+	if s.cards != nil {
+		s.drawCards(screen)
+		if s.answer {
+			DrawText(screen, "Click a card or press its number - Esc menu", Body, mapX, hint, DimColour)
+		} else {
+			DrawText(screen, "Click a card or press its number - Esc to turn back", Body, mapX, hint, DimColour)
+		}
+	} else {
+		DrawText(screen, "Arrows wander - drag or scroll the map - C centre - I items - J journal - M map - Esc menu", Body, mapX, hint, DimColour)
+	}
+}
+
+// drawMap draws the world through the camera, outlining the selected tile
+// and naming it above the map.
+func (s *GamePlay) drawMap(screen *ebiten.Image) {
+	drawView(screen, s.atlas, s.Journey, s.camera)
+	if s.selected == nil {
+		return
+	}
+
+	x, y := s.selected[0], s.selected[1]
+	v := s.camera.View
+	if r := s.camera.TileRect(x, y); r.X >= v.X && r.Y >= v.Y && r.X+r.W <= v.X+v.W && r.Y+r.H <= v.Y+v.H {
+		vector.StrokeRect(screen, float32(r.X)+1, float32(r.Y)+1, float32(r.W)-2, float32(r.H)-2, 2, selectColour, false)
+	}
+	name := "Unexplored"
+	if tile := s.Journey.GetTile(x, y); tile != nil {
+		name = tile.Theme
+	}
+	label := fmt.Sprintf("%s (%d, %d)", name, x, y)
+	DrawText(screen, label, Body, v.X+v.W-TextWidth(Body, label), 14, DimColour)
+}
+
+// drawPanel describes where the player stands, what the last step turned
+// up, and draws the direction buttons.
+func (s *GamePlay) drawPanel(screen *ebiten.Image, tile *lib.Tile) {
+	x, y := s.panelX, mapY
+	drawFrame(screen, s.atlas.Frame(sprites.Player), types.Rect{X: x, Y: y, W: portraitH, H: portraitH})
+	y += portraitH + 8
+
+	bottom := s.pad.List[0].Rect.Y - 8
+	DrawText(screen, tile.Theme, Title, x, y, TextColour)
+	y = DrawWrapped(screen, tile.Description, Body, x, y+LineHeight(Title), panelW, bottom, TextColour)
+	for _, message := range s.message {
+		y = DrawWrapped(screen, message, Body, x, y+lineH/2, panelW, bottom, DimColour)
+	}
+	if tile.Item != nil {
+		DrawWrapped(screen, "Here: "+tile.Item.Name, Body, x, y+lineH/2, panelW, bottom, itemColour)
+	}
+
+	// Directions still to explore are marked with a ?
+	for i, btn := range s.pad.List {
+		dir := lib.Directions[i]
+		btn.Label = dir.Name[:1]
+		if s.Journey.GetTile(s.Journey.CurrentX+dir.DX, s.Journey.CurrentY+dir.DY) == nil {
+			btn.Label += "?"
+		}
+	}
+	s.pad.Draw(screen)
+}
+
+// drawCards draws the choices over the map.
+func (s *GamePlay) drawCards(screen *ebiten.Image) {
+	v := s.camera.View
+	vector.DrawFilledRect(screen, float32(v.X), float32(v.Y), float32(v.W), float32(v.H), color.RGBA{0, 0, 0, 160}, false)
+	DrawWrapped(screen, s.heading, Body, v.X+6, v.Y+4, v.W-12, v.Y+2*lineH+4, TextColour)
+	s.cards.Draw(screen)
+}
+
+// cardRect is where card i is drawn over the map, below two lines of
+// heading.
+func (s *GamePlay) cardRect(i int) types.Rect {
+	v := s.camera.View
+	return types.Rect{X: v.X, Y: v.Y + 2*lineH + 8 + i*(cardH+cardGap), W: v.W, H: cardH}
+}
@@ -0,0 +1,58 @@
+package screens
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Input is where the screens read the keyboard and mouse. The game reads
+// ebiten's; a harness can play back its own to drive the screens without a
+// window.
+type Input interface {
+	CursorPosition() (x, y int)
+	Wheel() (x, y float64)
+	IsKeyPressed(key ebiten.Key) bool
+	IsKeyJustPressed(key ebiten.Key) bool
+	IsMouseButtonJustPressed(button ebiten.MouseButton) bool
+	IsMouseButtonJustReleased(button ebiten.MouseButton) bool
+	AppendInputChars(runes []rune) []rune
+}
+
+// input is the Input every screen reads
+var input Input = ebitenInput{}
+
+// SetInput makes the screens read in instead of ebiten's input.
+func SetInput(in Input) {
+	input = in
+}
+
+// ebitenInput is the keyboard and mouse of the window ebiten opened.
+type ebitenInput struct{}
+
+func (ebitenInput) CursorPosition() (int, int) {
+	return ebiten.CursorPosition()
+}
+
+func (ebitenInput) Wheel() (float64, float64) {
+	return ebiten.Wheel()
+}
+
+func (ebitenInput) IsKeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (ebitenInput) IsKeyJustPressed(key ebiten.Key) bool {
+	return inpututil.IsKeyJustPressed(key)
+}
+
+func (ebitenInput) IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustPressed(button)
+}
+
+func (ebitenInput) IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	return inpututil.IsMouseButtonJustReleased(button)
+}
+
+func (ebitenInput) AppendInputChars(runes []rune) []rune {
+	return ebiten.AppendInputChars(runes)
+}
@@ -0,0 +1,257 @@
+package screens
+
+import (
+	"GentleWanderings/gui_game/types"
+	"GentleWanderings/lib"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// The Back button, and the buttons that turn the pages of slots beside it
+var (
+	loadBack = types.Rect{X: 20, Y: 430, W: 80, H: 30}
+	loadPrev = types.Rect{X: 440, Y: 430, W: 80, H: 30}
+	loadNext = types.Rect{X: 530, Y: 430, W: 80, H: 30}
+)
+
+const (
+	slotRowTop    = 40
+	slotRowHeight = 36
+	slotActionW   = 44
+	slotActionH   = 24
+	slotMessageY  = 400
+	slotsPerPage  = (slotMessageY - slotRowTop) / slotRowHeight
+)
+
+// slotActions are the per-slot buttons drawn at the right of each row.
+var slotActions = []struct {
+	label string
+	x     int
+	clr   color.RGBA
+}{
+	{"Load", 400, color.RGBA{0, 150, 0, 255}},
+	{"Copy", 450, color.RGBA{0, 0, 150, 255}},
+	{"Name", 500, color.RGBA{120, 90, 0, 255}},
+	{"Del", 550, color.RGBA{150, 0, 0, 255}},
+}
+
+// LoadMenu is the slot browser opened from the main menu's Load button.
+type LoadMenu struct {
+	fixedLayout
+	slots    *lib.Slots
+	list     []lib.SlotInfo
+	page     int // which slotsPerPage slots are shown
+	buttons  *Buttons
+	rows     int // where the slot buttons start in buttons
+	message  string
+	renaming int    // index of the slot being renamed, or -1
+	newName  []rune // name typed so far while renaming
+	deleting int    // index of the slot awaiting delete confirmation, or -1
+}
+
+func NewLoadMenu(slots *lib.Slots) *LoadMenu {
+	m := &LoadMenu{slots: slots, renaming: -1, deleting: -1}
+	m.Refresh()
+	return m
+}
+
+// Refresh re-reads the save directory and lays out a row of buttons for
+// each slot on the page.
+func (m *LoadMenu) Refresh() {
+	list, err := m.slots.List()
+	if err != nil {
+		m.message = fmt.Sprintf("Could not read save slots: %v", err)
+		list = nil
+	}
+	m.list = list
+	m.turnTo(m.page)
+}
+
+// pages is how many pages the slots fill.
+func (m *LoadMenu) pages() int {
+	return max((len(m.list)+slotsPerPage-1)/slotsPerPage, 1)
+}
+
+// turnTo shows page, or the nearest there is, with a row of buttons for
+// each slot on it.
+func (m *LoadMenu) turnTo(page int) {
+	m.page = max(min(page, m.pages()-1), 0)
+	m.renaming = -1
+	m.deleting = -1
+	m.buttons = NewButtons(false, NewButton("Back", loadBack.X, loadBack.Y, loadBack.W, loadBack.H, color.RGBA{150, 0, 0, 255}, func(manager *Manager) error {
+		manager.Pop()
+		return nil
+	}))
+	if m.page > 0 {
+		m.buttons.List = append(m.buttons.List, NewButton("Prev", loadPrev.X, loadPrev.Y, loadPrev.W, loadPrev.H, color.RGBA{0, 0, 150, 255}, func(*Manager) error {
+			m.turnTo(m.page - 1)
+			return nil
+		}))
+	}
+	if m.page < m.pages()-1 {
+		m.buttons.List = append(m.buttons.List, NewButton("Next", loadNext.X, loadNext.Y, loadNext.W, loadNext.H, color.RGBA{0, 0, 150, 255}, func(*Manager) error {
+			m.turnTo(m.page + 1)
+			return nil
+		}))
+	}
+
+	m.rows = len(m.buttons.List)
+	first, last := m.shown()
+	for i := first; i < last; i++ {
+		y := slotRowTop + (i-first)*slotRowHeight
+		for _, action := range slotActions {
+			m.buttons.List = append(m.buttons.List, NewButton(action.label, action.x, y, slotActionW, slotActionH, action.clr, func(manager *Manager) error {
+				return m.act(manager, action.label, i)
+			}))
+		}
+	}
+}
+
+// shown returns the slots on the page, from first up to last.
+func (m *LoadMenu) shown() (first, last int) {
+	first = m.page * slotsPerPage
+	return first, min(first+slotsPerPage, len(m.list))
+}
+
+// button returns the button for slotActions[action] on the row of slot i.
+func (m *LoadMenu) button(i, action int) *Button {
+	first, _ := m.shown()
+	return m.buttons.List[m.rows+(i-first)*len(slotActions)+action]
+}
+
+// Update handles input, and starts the journey once a slot is loaded.
+func (m *LoadMenu) clickable() []*Button {
+	return m.buttons.List
+}
+
+func (m *LoadMenu) Update(manager *Manager) error {
+	if m.renaming >= 0 {
+		m.updateRename()
+		return nil
+	}
+	if input.IsKeyJustPressed(ebiten.KeyEscape) {
+		manager.Pop()
+		return nil
+	}
+
+	// Page Up and Down and the wheel turn the pages too
+	_, wheel := input.Wheel()
+	switch {
+	case (input.IsKeyJustPressed(ebiten.KeyPageUp) || wheel > 0) && m.page > 0:
+		m.turnTo(m.page - 1)
+		return nil
+	case (input.IsKeyJustPressed(ebiten.KeyPageDown) || wheel < 0) && m.page < m.pages()-1:
+		m.turnTo(m.page + 1)
+		return nil
+	}
+	return m.buttons.Update(manager)
+}
+
+// act runs one of the slotActions on slot i.
+func (m *LoadMenu) act(manager *Manager, action string, i int) error {
+	slot := m.list[i]
+	confirmDelete := m.deleting == i
+	m.deleting = -1
+	m.message = ""
+
+	switch action {
+	case "Load":
+		loaded, err := m.slots.Load(slot.Name)
+		if err != nil {
+			m.message = fmt.Sprintf("Could not load %q: %v", slot.Name, err)
+			return nil
+		}
+		manager.Switch(NewGamePlay(loaded, manager.Atlas))
+	case "Copy":
+		if err := m.slots.Duplicate(slot.Name, slot.Name+" copy"); err != nil {
+			m.message = fmt.Sprintf("Could not copy %q: %v", slot.Name, err)
+			return nil
+		}
+		m.Refresh()
+	case "Name":
+		m.renaming = i
+		m.newName = []rune(slot.Name)
+	case "Del":
+		if !confirmDelete {
+			m.deleting = i
+			m.message = fmt.Sprintf("Click Del again to delete %q forever.", slot.Name)
+			return nil
+		}
+		if err := m.slots.Delete(slot.Name); err != nil {
+			m.message = fmt.Sprintf("Could not delete %q: %v", slot.Name, err)
+			return nil
+		}
+		m.Refresh()
+	}
+	return nil
+}
+
+// updateRename collects typed characters for a new slot name.
+func (m *LoadMenu) updateRename() {
+	m.newName = input.AppendInputChars(m.newName)
+
+	if input.IsKeyJustPressed(ebiten.KeyBackspace) && len(m.newName) > 0 {
+		m.newName = m.newName[:len(m.newName)-1]
+	}
+	if input.IsKeyJustPressed(ebiten.KeyEscape) {
+		m.renaming = -1
+		return
+	}
+	if input.IsKeyJustPressed(ebiten.KeyEnter) {
+		slot := m.list[m.renaming]
+		m.renaming = -1
+		if string(m.newName) == slot.Name {
+			return
+		}
+		if err := m.slots.Rename(slot.Name, string(m.newName)); err != nil {
+			m.message = fmt.Sprintf("Could not rename %q: %v", slot.Name, err)
+			return
+		}
+		m.message = ""
+		m.Refresh()
+	}
+}
+
+func (m *LoadMenu) Draw(screen *ebiten.Image) {
+	DrawText(screen, "Load a Journey", Title, 20, 10, TextColour)
+
+	if len(m.list) == 0 {
+		DrawText(screen, "No journeys have been saved yet.", Body, 20, slotRowTop, TextColour)
+	}
+
+	first, last := m.shown()
+	for i := first; i < last; i++ {
+		slot := m.list[i]
+		y := slotRowTop + (i-first)*slotRowHeight
+		name := slot.Name
+		if i == m.renaming {
+			name = string(m.newName) + "_"
+		}
+		DrawText(screen, name, Body, 20, y-2, TextColour)
+		summary := fmt.Sprintf("Day %d - %d locations - %s", slot.Day, slot.Tiles, slot.LastPlayed.Format("2006-01-02 15:04"))
+		if slot.Damaged != nil {
+			summary = "Damaged - it can't be read"
+		}
+		DrawText(screen, summary, Body, 20, y+LineHeight(Body)-4, DimColour)
+
+		// Del asks again before deleting
+		del := m.button(i, len(slotActions)-1)
+		del.Label = "Del"
+		if i == m.deleting {
+			del.Label = "Sure?"
+		}
+	}
+	m.buttons.Draw(screen)
+
+	if m.pages() > 1 {
+		page := fmt.Sprintf("Page %d of %d", m.page+1, m.pages())
+		DrawText(screen, page, Body, loadPrev.X-TextWidth(Body, page)-12, loadBack.Y+(loadBack.H-LineHeight(Body))/2, DimColour)
+	}
+	if m.renaming >= 0 {
+		DrawText(screen, "Type a new name, Enter to keep it, Esc to cancel.", Body, 20, slotMessageY, DimColour)
+	} else if m.message != "" {
+		DrawText(screen, m.message, Body, 20, slotMessageY, TextColour)
+	}
+}
@@ -1 +1,51 @@
 package screens
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MainMenu is the first screen: start a journey, load one, change the
+// options or quit.
+type MainMenu struct {
+	fixedLayout
+	buttons *Buttons
+}
+
+func NewMainMenu() *MainMenu {
+	return &MainMenu{
+		buttons: NewButtons(true,
+			NewButton("New", 50, 50, 120, 60, color.RGBA{0, 150, 0, 255}, func(m *Manager) error {
+				m.Switch(NewGamePlay(m.NewJourney(), m.Atlas))
+				return nil
+			}),
+			NewButton("Load", 50, 150, 120, 60, color.RGBA{0, 0, 150, 255}, func(m *Manager) error {
+				m.Push(NewLoadMenu(m.Slots))
+				return nil
+			}),
+			NewButton("Options", 50, 250, 120, 60, color.RGBA{150, 0, 0, 255}, func(m *Manager) error {
+				m.Push(NewOptions(m))
+				return nil
+			}),
+			NewButton("Quit", 50, 350, 120, 60, color.RGBA{150, 0, 0, 255}, func(m *Manager) error {
+				return ebiten.Termination
+			}),
+		),
+	}
+}
+
+func (s *MainMenu) clickable() []*Button {
+	return s.buttons.List
+}
+
+func (s *MainMenu) Update(m *Manager) error {
+	return s.buttons.Update(m)
+}
+
+func (s *MainMenu) Draw(screen *ebiten.Image) {
+	DrawText(screen, "Gentle Wanderings", Title, 220, 60, TextColour)
+	DrawWrapped(screen, "A cozy, meditative exploration of a world that unfolds one gentle step at a time.",
+		Body, 220, 60+LineHeight(Title)+8, 360, ScreenHeight, DimColour)
+	s.buttons.Draw(screen)
+}
@@ -0,0 +1,282 @@
+package screens
+
+import (
+	"GentleWanderings/gui_game/sprites"
+	"GentleWanderings/gui_game/types"
+	"GentleWanderings/lib"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// panelRect is where overlays draw their panel
+var panelRect = types.Rect{X: 40, Y: 32, W: ScreenWidth - 80, H: ScreenHeight - 64}
+
+var (
+	shadeColour = color.RGBA{0, 0, 0, 150}
+	panelColour = color.RGBA{40, 46, 60, 250}
+	rowColour   = color.RGBA{56, 62, 80, 255}
+)
+
+// drawPanel dims whatever is underneath and draws a titled panel with a
+// hint along its bottom edge.
+func drawPanel(screen *ebiten.Image, title, hint string) {
+	vector.DrawFilledRect(screen, 0, 0, ScreenWidth, ScreenHeight, shadeColour, false)
+	r := panelRect
+	vector.DrawFilledRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), panelColour, false)
+	DrawText(screen, title, Title, r.X+12, r.Y+8, TextColour)
+	DrawText(screen, hint, Body, r.X+12, r.Y+r.H-LineHeight(Body)-8, DimColour)
+}
+
+// panelTop is the y of the first line under a panel's title
+var panelTop = panelRect.Y + 16 + LineHeight(Title)
+
+// panelLines is how many lines of text fit between the title and the hint
+var panelLines = (panelRect.Y + panelRect.H - LineHeight(Body) - 16 - panelTop) / LineHeight(Body)
+
+// closePressed reports whether Esc or key was pressed to close an overlay.
+func closePressed(key ebiten.Key) bool {
+	return input.IsKeyJustPressed(ebiten.KeyEscape) || input.IsKeyJustPressed(key)
+}
+
+// TextOverlay shows a scrolling page of text over the journey, such as the
+// inventory or the journal.
+type TextOverlay struct {
+	fixedLayout
+	title  string
+	key    ebiten.Key // the key that opened it, which also closes it
+	lines  []string
+	scroll int // index of the first line shown
+}
+
+func (*TextOverlay) Overlay() {}
+
+// NewInventory lists the items collected so far by category.
+func NewInventory(g *lib.Game) *TextOverlay {
+	lines := []string{}
+	for _, group := range g.InventoryGroups() {
+		lines = append(lines, group.Label)
+		for _, item := range group.Items {
+			lines = append(lines, fmt.Sprintf("  %s - found at %s on day %d", item.Name, item.FoundAt, item.FoundDay))
+			lines = append(lines, wrap("      "+item.Description, Body, panelRect.W-24)...)
+		}
+		lines = append(lines, "")
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "Your pack is empty for now.")
+	}
+	return &TextOverlay{title: "Inventory", key: ebiten.KeyI, lines: lines}
+}
+
+// NewJournal shows the journey log, scrolled to the newest entries.
+func NewJournal(g *lib.Game) *TextOverlay {
+	lines := []string{}
+	for _, entry := range g.JournalLog {
+		for _, line := range strings.Split(entry.String(), "\n") {
+			lines = append(lines, wrap(line, Body, panelRect.W-24)...)
+		}
+	}
+	return &TextOverlay{title: "Journal", key: ebiten.KeyJ, lines: lines, scroll: max(len(lines)-panelLines, 0)}
+}
+
+func (s *TextOverlay) Update(m *Manager) error {
+	if closePressed(s.key) {
+		m.Pop()
+		return nil
+	}
+
+	_, wheel := input.Wheel()
+	switch {
+	case input.IsKeyJustPressed(ebiten.KeyArrowUp) || wheel > 0:
+		s.scroll--
+	case input.IsKeyJustPressed(ebiten.KeyArrowDown) || wheel < 0:
+		s.scroll++
+	case input.IsKeyJustPressed(ebiten.KeyPageUp):
+		s.scroll -= panelLines
+	case input.IsKeyJustPressed(ebiten.KeyPageDown):
+		s.scroll += panelLines
+	}
+	s.scroll = max(min(s.scroll, len(s.lines)-panelLines), 0)
+	return nil
+}
+
+func (s *TextOverlay) Draw(screen *ebiten.Image) {
+	drawPanel(screen, s.title, "Up/Down or the wheel to scroll - Esc to close")
+	end := min(s.scroll+panelLines, len(s.lines))
+	for i, line := range s.lines[s.scroll:end] {
+		DrawText(screen, line, Body, panelRect.X+12, panelTop+i*LineHeight(Body), TextColour)
+	}
+}
+
+// MapOverlay shows a larger map that can be scrolled and zoomed out.
+type MapOverlay struct {
+	fixedLayout
+	journey *lib.Game
+	atlas   *sprites.Atlas
+}
+
+func (*MapOverlay) Overlay() {}
+
+const overlayCell = 16
+
+func NewMapOverlay(g *lib.Game, atlas *sprites.Atlas) *MapOverlay {
+	return &MapOverlay{journey: g, atlas: atlas}
+}
+
+func (s *MapOverlay) Update(m *Manager) error {
+	if closePressed(ebiten.KeyM) {
+		m.Pop()
+		return nil
+	}
+	for i, key := range []ebiten.Key{ebiten.KeyArrowUp, ebiten.KeyArrowDown, ebiten.KeyArrowRight, ebiten.KeyArrowLeft} {
+		if input.IsKeyJustPressed(key) {
+			s.journey.PanMap(lib.Directions[i])
+		}
+	}
+	if input.IsKeyJustPressed(ebiten.KeyF) {
+		s.journey.FitMap()
+	}
+	if input.IsKeyJustPressed(ebiten.KeyC) {
+		s.journey.CenterMap()
+	}
+	return nil
+}
+
+func (s *MapOverlay) Draw(screen *ebiten.Image) {
+	drawPanel(screen, "Your Map", "Arrows scroll - F fits everything - C centres on you - Esc to close")
+
+	cols := (panelRect.W - 24) / overlayCell
+	space := panelLines * LineHeight(Body)
+	grid := s.journey.MapWindow(cols, space/overlayCell)
+	if grid.Scale > 1 {
+		scale := fmt.Sprintf("1:%d", grid.Scale)
+		DrawText(screen, scale, Body, panelRect.X+panelRect.W-12-TextWidth(Body, scale), panelRect.Y+8, DimColour)
+	}
+
+	// Centre the grid in the panel
+	width, height := len(grid.Cells[0])*overlayCell, len(grid.Cells)*overlayCell
+	left := panelRect.X + (panelRect.W-width)/2
+	top := panelTop + (space-height)/2
+	drawWorld(screen, s.atlas, s.journey, grid, left, top, overlayCell)
+}
+
+// menuRow is one choice in a MenuOverlay.
+type menuRow struct {
+	label  func() string
+	action func(m *Manager) error
+}
+
+// MenuOverlay is a list of choices over the current screen, picked with the
+// mouse or the arrow keys and Enter.
+type MenuOverlay struct {
+	fixedLayout
+	title   string
+	rows    []menuRow
+	buttons *Buttons
+	message string
+}
+
+func (*MenuOverlay) Overlay() {}
+
+// newMenu lays out rows as a column of buttons.
+func newMenu(title string, rows []menuRow) *MenuOverlay {
+	s := &MenuOverlay{title: title, rows: rows, buttons: NewButtons(true)}
+	for i, row := range rows {
+		r := rowRect(i)
+		s.buttons.List = append(s.buttons.List, NewButton(row.label(), r.X, r.Y, r.W, r.H, rowColour, row.action))
+	}
+	s.buttons.focus = 0
+	return s
+}
+
+// NewPause is the menu Esc opens during a journey.
+func NewPause(g *lib.Game) *MenuOverlay {
+	var s *MenuOverlay
+	s = newMenu("Paused", []menuRow{
+		{label: fixedLabel("Resume"), action: func(m *Manager) error {
+			m.Pop()
+			return nil
+		}},
+		{label: fixedLabel("Save to \"" + quickSave + "\""), action: func(m *Manager) error {
+			if err := m.Slots.Save(quickSave, g); err != nil {
+				s.message = fmt.Sprintf("Could not save your journey: %v", err)
+			} else {
+				s.message = fmt.Sprintf("Your journey has been saved as %q.", quickSave)
+			}
+			return nil
+		}},
+		{label: fixedLabel("Options"), action: func(m *Manager) error {
+			m.Push(NewOptions(m))
+			return nil
+		}},
+		{label: fixedLabel("Main Menu"), action: func(m *Manager) error {
+			m.Switch(NewMainMenu())
+			return nil
+		}},
+	})
+	return s
+}
+
+// quickSave is the slot the pause menu saves to
+const quickSave = "Quick save"
+
+// NewOptions lets the player change how the GUI run by m behaves.
+func NewOptions(m *Manager) *MenuOverlay {
+	return newMenu("Options", []menuRow{
+		{label: func() string { return "Fullscreen: " + onOff(ebiten.IsFullscreen()) }, action: func(m *Manager) error {
+			ebiten.SetFullscreen(!ebiten.IsFullscreen())
+			return nil
+		}},
+		{label: func() string { return "Fade between screens: " + onOff(m.Fade) }, action: func(m *Manager) error {
+			m.Fade = !m.Fade
+			return nil
+		}},
+		{label: fixedLabel("Back"), action: func(m *Manager) error {
+			m.Pop()
+			return nil
+		}},
+	})
+}
+
+func fixedLabel(label string) func() string {
+	return func() string { return label }
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// rowRect is where row i of a menu is drawn
+func rowRect(i int) types.Rect {
+	h := LineHeight(Body) + 12
+	return types.Rect{X: panelRect.X + 12, Y: panelTop + i*(h+8), W: panelRect.W - 24, H: h}
+}
+
+func (s *MenuOverlay) clickable() []*Button {
+	return s.buttons.List
+}
+
+func (s *MenuOverlay) Update(m *Manager) error {
+	if input.IsKeyJustPressed(ebiten.KeyEscape) {
+		m.Pop()
+		return nil
+	}
+	return s.buttons.Update(m)
+}
+
+func (s *MenuOverlay) Draw(screen *ebiten.Image) {
+	drawPanel(screen, s.title, "Up/Down and Enter, or click - Esc to go back")
+	for i, row := range s.rows {
+		s.buttons.List[i].Label = row.label()
+	}
+	s.buttons.Draw(screen)
+	if s.message != "" {
+		DrawText(screen, s.message, Body, panelRect.X+16, rowRect(len(s.rows)).Y+8, TextColour)
+	}
+}
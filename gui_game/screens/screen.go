@@ -0,0 +1,176 @@
+package screens
+
+import (
+	"GentleWanderings/gui_game/sprites"
+	"GentleWanderings/lib"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// The size the screens are drawn at; ebiten scales it to the window
+const (
+	ScreenWidth  = 640
+	ScreenHeight = 480
+)
+
+// fadeFrames is how long each half of a fade between screens lasts
+const fadeFrames = 15
+
+// Screen is one scene of the GUI: a menu, the journey, or an overlay such
+// as the inventory. Only the screen on top of the stack is updated.
+type Screen interface {
+	Update(m *Manager) error
+	Draw(screen *ebiten.Image)
+	Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int)
+}
+
+// Overlay is a screen drawn over the one beneath it instead of replacing
+// it, like a modal dialog. Overlays open and close without a fade.
+type Overlay interface {
+	Screen
+	Overlay()
+}
+
+// Manager is the ebiten.Game that runs a stack of screens, fading between
+// them when a full screen is pushed, popped or switched to.
+type Manager struct {
+	Slots      *lib.Slots       // where journeys are saved
+	Atlas      *sprites.Atlas   // the sprites the world is drawn with
+	Fade       bool             // fade between screens
+	NewJourney func() *lib.Game // starts the journey New begins
+
+	stack   []Screen
+	fade    int    // frames into the current fade, or 0 when not fading
+	pending func() // the stack change waiting for the screen to go dark
+}
+
+// NewManager creates a manager showing first.
+func NewManager(first Screen, slots *lib.Slots, atlas *sprites.Atlas) *Manager {
+	return &Manager{Slots: slots, Atlas: atlas, Fade: true, NewJourney: lib.NewGame, stack: []Screen{first}}
+}
+
+// Top returns the screen on top of the stack.
+func (m *Manager) Top() Screen {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// Fading reports whether the manager is fading between screens, when no
+// screen is updated.
+func (m *Manager) Fading() bool {
+	return m.fade > 0
+}
+
+// Journey returns the journey being played on the stack, if any.
+func (m *Manager) Journey() *lib.Game {
+	for _, s := range m.stack {
+		if play, ok := s.(*GamePlay); ok {
+			return play.Journey
+		}
+	}
+	return nil
+}
+
+// ButtonsOf returns the buttons that can be clicked on s.
+func ButtonsOf(s Screen) []*Button {
+	if c, ok := s.(interface{ clickable() []*Button }); ok {
+		return c.clickable()
+	}
+	return nil
+}
+
+// Push opens s over the current screen.
+func (m *Manager) Push(s Screen) {
+	m.change(s, func() { m.stack = append(m.stack, s) })
+}
+
+// Pop closes the top screen, going back to the one beneath it.
+func (m *Manager) Pop() {
+	if len(m.stack) <= 1 {
+		return
+	}
+	m.change(m.Top(), func() { m.stack = m.stack[:len(m.stack)-1] })
+}
+
+// Switch replaces every screen with s, as when a journey starts or ends.
+func (m *Manager) Switch(s Screen) {
+	m.change(s, func() { m.stack = []Screen{s} })
+}
+
+// change applies a stack change at once for overlays, or halfway through a
+// fade for full screens.
+func (m *Manager) change(s Screen, apply func()) {
+	if _, overlay := s.(Overlay); overlay || !m.Fade {
+		apply()
+		return
+	}
+	m.pending = apply
+	m.fade = 1
+}
+
+// Update advances any fade, then updates the top screen.
+func (m *Manager) Update() error {
+	if m.fade > 0 {
+		m.fade++
+		if m.fade == fadeFrames && m.pending != nil {
+			m.pending()
+			m.pending = nil
+		}
+		if m.fade >= 2*fadeFrames {
+			m.fade = 0
+		}
+		return nil
+	}
+	if top := m.Top(); top != nil {
+		return top.Update(m)
+	}
+	return ebiten.Termination
+}
+
+// Draw draws the top screen, and the screens under it if it is an overlay.
+func (m *Manager) Draw(screen *ebiten.Image) {
+	bottom := len(m.stack) - 1
+	for bottom > 0 {
+		if _, overlay := m.stack[bottom].(Overlay); !overlay {
+			break
+		}
+		bottom--
+	}
+	for _, s := range m.stack[max(bottom, 0):] {
+		s.Draw(screen)
+	}
+
+	if m.fade > 0 {
+		// Darken towards the middle of the fade and lighten after it
+		dark := 1 - float32(abs(m.fade-fadeFrames))/fadeFrames
+		bounds := screen.Bounds()
+		vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()),
+			color.RGBA{0, 0, 0, uint8(dark * 255)}, false)
+	}
+}
+
+// Layout asks the top screen how large to draw.
+func (m *Manager) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if top := m.Top(); top != nil {
+		return top.Layout(outsideWidth, outsideHeight)
+	}
+	return ScreenWidth, ScreenHeight
+}
+
+// fixedLayout gives a screen the fixed ScreenWidth x ScreenHeight layout.
+type fixedLayout struct{}
+
+func (fixedLayout) Layout(_, _ int) (int, int) {
+	return ScreenWidth, ScreenHeight
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+package screens
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// Faces the GUI writes with, made from the Go fonts built into x/image so
+// nothing has to be loaded from disk
+var (
+	Body  = mustFace(goregular.TTF, 13)
+	Title = mustFace(gobold.TTF, 18)
+)
+
+// Colours text is drawn in
+var (
+	TextColour = color.RGBA{236, 232, 220, 255}
+	DimColour  = color.RGBA{160, 164, 176, 255}
+)
+
+func mustFace(ttf []byte, size float64) text.Face {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(ttf))
+	if err != nil {
+		panic(err)
+	}
+	return &text.GoTextFace{Source: source, Size: size}
+}
+
+// LineHeight is how far apart lines of face are drawn.
+func LineHeight(face text.Face) int {
+	m := face.Metrics()
+	return int(math.Ceil(m.HAscent+m.HDescent+m.HLineGap)) + 2
+}
+
+// TextWidth is how many pixels across s is in face.
+func TextWidth(face text.Face, s string) int {
+	return int(math.Ceil(text.Advance(s, face)))
+}
+
+// DrawText draws one line of text with its top left corner at x, y.
+func DrawText(screen *ebiten.Image, s string, face text.Face, x, y int, clr color.Color) {
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(clr)
+	text.Draw(screen, s, face, op)
+}
+
+// DrawWrapped draws text wrapped to width pixels, stopping before maxY, and
+// returns the y below the last line drawn.
+func DrawWrapped(screen *ebiten.Image, s string, face text.Face, x, y, width, maxY int, clr color.Color) int {
+	step := LineHeight(face)
+	for _, line := range wrap(s, face, width) {
+		if y+step > maxY {
+			break
+		}
+		DrawText(screen, line, face, x, y, clr)
+		y += step
+	}
+	return y
+}
+
+// wrap breaks text into lines no wider than width pixels, at spaces where
+// it can. Existing line breaks are kept, along with the indent each starts
+// with.
+func wrap(s string, face text.Face, width int) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(s, "\n") {
+		indent := paragraph[:len(paragraph)-len(strings.TrimLeft(paragraph, " "))]
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = indent + word
+			case TextWidth(face, line+" "+word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = indent + word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
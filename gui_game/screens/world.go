@@ -0,0 +1,96 @@
+package screens
+
+import (
+	"GentleWanderings/gui_game/sprites"
+	"GentleWanderings/gui_game/types"
+	"GentleWanderings/lib"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// drawWorld draws a map grid as tiles from the atlas, with its top left
+// corner at left, top and each tile size pixels across. Explored places
+// show their biome's ground with their theme over it, anything found there
+// on top, and the player stands on CurrentX, CurrentY. Fog hides the rest,
+// lifting a little along the frontier.
+func drawWorld(screen *ebiten.Image, atlas *sprites.Atlas, g *lib.Game, grid *lib.MapGrid, left, top, size int) {
+	for row, cells := range grid.Cells {
+		for col, cell := range cells {
+			r := types.Rect{X: left + col*size, Y: top + row*size, W: size, H: size}
+			drawCell(screen, atlas, g, cell, grid.Tiles[row][col], r)
+		}
+	}
+
+	// A zoomed out grid squeezes several tiles into a cell, so find the one
+	// the player is in
+	col := floorDiv(g.CurrentX-grid.MinX, grid.Scale)
+	row := floorDiv(grid.MaxY-g.CurrentY, grid.Scale)
+	if row >= 0 && row < len(grid.Cells) && col >= 0 && col < len(grid.Cells[row]) {
+		drawFrame(screen, atlas.Frame(sprites.Player), types.Rect{X: left + col*size, Y: top + row*size, W: size, H: size})
+	}
+}
+
+// drawView draws the world as the camera sees it, clipped to its view.
+func drawView(screen *ebiten.Image, atlas *sprites.Atlas, g *lib.Game, cam *Camera) {
+	v := cam.View
+	view := screen.SubImage(image.Rect(v.X, v.Y, v.X+v.W, v.Y+v.H)).(*ebiten.Image)
+
+	// The grid covers the explored land and its frontier; fog lies beyond
+	grid := g.MapGrid(nil)
+	minX, maxX, minY, maxY := cam.Visible()
+	for y := maxY; y >= minY; y-- {
+		for x := minX; x <= maxX; x++ {
+			cell, tile := lib.CellFog, (*lib.Tile)(nil)
+			if row, col := grid.MaxY-y, x-grid.MinX; row >= 0 && row < len(grid.Cells) && col >= 0 && col < len(grid.Cells[row]) {
+				cell, tile = grid.Cells[row][col], grid.Tiles[row][col]
+			}
+			drawCell(view, atlas, g, cell, tile, cam.TileRect(x, y))
+		}
+	}
+	drawFrame(view, atlas.Frame(sprites.Player), cam.TileRect(g.CurrentX, g.CurrentY))
+}
+
+// drawCell draws what one square of the map shows.
+func drawCell(screen *ebiten.Image, atlas *sprites.Atlas, g *lib.Game, cell lib.MapCell, tile *lib.Tile, r types.Rect) {
+	switch cell {
+	case lib.CellFog:
+		drawFrame(screen, atlas.Frame(sprites.Fog), r)
+		return
+	case lib.CellFrontier:
+		drawFrame(screen, atlas.Frame(sprites.Frontier), r)
+		return
+	}
+
+	drawFrame(screen, atlas.Biome(g.TileBiome(tile)), r)
+	if tile == nil {
+		return
+	}
+	if theme := atlas.Theme(tile.Theme); theme != nil {
+		drawFrame(screen, theme, r)
+	}
+	if tile.Item != nil {
+		drawFrame(screen, atlas.Item(tile.Item.Category), r)
+	}
+}
+
+// drawFrame draws frame scaled to fill r, keeping its shape and sitting it
+// on the bottom of r.
+func drawFrame(screen *ebiten.Image, frame *ebiten.Image, r types.Rect) {
+	w, h := frame.Bounds().Dx(), frame.Bounds().Dy()
+	scale := min(float64(r.W)/float64(w), float64(r.H)/float64(h))
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(r.X)+(float64(r.W)-float64(w)*scale)/2, float64(r.Y+r.H)-float64(h)*scale)
+	screen.DrawImage(frame, op)
+}
+
+// floorDiv divides rounding down, so tiles left of or below the grid fall
+// outside it.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,220 @@
+// Package sprites loads the sprite atlas the GUI draws the world with: one
+// PNG sheet and a JSON index naming the frames on it.
+package sprites
+
+import (
+	"GentleWanderings/lib/content"
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	_ "image/png" // decodes the atlas and any override frames
+	"os"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// The atlas built into the game
+var (
+	//go:embed atlas.png
+	atlasPNG []byte
+	//go:embed atlas.json
+	atlasJSON []byte
+)
+
+// Frame names the atlas is expected to have besides the biomes, themes and
+// item categories
+const (
+	Fog      = "fog"
+	Frontier = "frontier"
+	Player   = "player"
+	Ground   = "ground" // land with no known biome
+	AnyItem  = "item"   // an item with no frame for its category
+)
+
+// index is the JSON side of an atlas: where each frame sits on the sheet,
+// and which frame draws each theme.
+type index struct {
+	Image  string            `json:"image"` // the sheet, relative to the index
+	Size   int               `json:"size"`  // the width and height frames are drawn at
+	Frames map[string]frame  `json:"frames"`
+	Themes map[string]string `json:"themes"`
+}
+
+type frame struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// Atlas holds every frame ready to draw. Asking for a frame it doesn't have
+// gives a placeholder square instead, so a missing sprite never stops the
+// game.
+type Atlas struct {
+	Size   int
+	frames map[string]*ebiten.Image
+	themes map[string]string
+}
+
+// Load builds the atlas from the one built into the game, then from
+// overrideDir: frames and themes in an atlas.json there replace those of
+// the same name, and a <frame>.png replaces that one frame. A broken override is reported but
+// the rest of the atlas is still returned.
+func Load(overrideDir string) (*Atlas, error) {
+	a := &Atlas{frames: map[string]*ebiten.Image{}, themes: map[string]string{}}
+	if err := a.add(atlasJSON, atlasPNG); err != nil {
+		return a, fmt.Errorf("built-in atlas: %w", err)
+	}
+	if overrideDir == "" {
+		return a, nil
+	}
+
+	var errs []error
+	if data, err := os.ReadFile(filepath.Join(overrideDir, "atlas.json")); err == nil {
+		errs = append(errs, a.addFile(overrideDir, data))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, err)
+	}
+
+	pngs, _ := filepath.Glob(filepath.Join(overrideDir, "*.png"))
+	for _, path := range pngs {
+		name := filepath.Base(path)
+		name = name[:len(name)-len(".png")]
+		if name == "atlas" {
+			continue
+		}
+		img, err := decodeFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		a.frames[name] = ebiten.NewImageFromImage(img)
+	}
+	return a, errors.Join(errs...)
+}
+
+// addFile adds an atlas from the index data read from dir, whose sheet lies
+// beside it.
+func (a *Atlas) addFile(dir string, data []byte) error {
+	var i index
+	if err := json.Unmarshal(data, &i); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Join(dir, "atlas.json"), err)
+	}
+	sheet := i.Image
+	if sheet == "" {
+		sheet = "atlas.png"
+	}
+	img, err := decodeFile(filepath.Join(dir, sheet))
+	if err != nil {
+		return err
+	}
+	a.addSheet(i, img)
+	return nil
+}
+
+// add adds the atlas described by the index data, cut from the PNG sheet.
+func (a *Atlas) add(data, sheet []byte) error {
+	var i index
+	if err := json.Unmarshal(data, &i); err != nil {
+		return err
+	}
+	img, _, err := image.Decode(bytes.NewReader(sheet))
+	if err != nil {
+		return err
+	}
+	a.addSheet(i, img)
+	return nil
+}
+
+func (a *Atlas) addSheet(i index, img image.Image) {
+	if i.Size > 0 {
+		a.Size = i.Size
+	}
+	sheet := ebiten.NewImageFromImage(img)
+	for name, f := range i.Frames {
+		r := image.Rect(f.X, f.Y, f.X+f.W, f.Y+f.H).Intersect(sheet.Bounds())
+		if r.Empty() {
+			continue
+		}
+		a.frames[name] = sheet.SubImage(r).(*ebiten.Image)
+	}
+	for theme, frame := range i.Themes {
+		a.themes[theme] = frame
+	}
+}
+
+func decodeFile(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return img, nil
+}
+
+// Has reports whether the atlas has a frame called name.
+func (a *Atlas) Has(name string) bool {
+	return a.frames[name] != nil
+}
+
+// Frame returns the frame called name, or a placeholder square in a colour
+// picked from the name if there isn't one.
+func (a *Atlas) Frame(name string) *ebiten.Image {
+	if frame := a.frames[name]; frame != nil {
+		return frame
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	sum := h.Sum32()
+	return a.placeholder(name, color.RGBA{uint8(sum>>16) | 64, uint8(sum>>8) | 64, uint8(sum) | 64, 255})
+}
+
+// placeholder makes and keeps a plain square of clr to stand in for name.
+func (a *Atlas) placeholder(name string, clr color.Color) *ebiten.Image {
+	size := max(a.Size, 1)
+	img := ebiten.NewImage(size, size)
+	img.Fill(clr)
+	a.frames[name] = img
+	return img
+}
+
+// Biome returns the ground of biome b, or a square of the biome's colour if
+// the atlas doesn't have it.
+func (a *Atlas) Biome(b *content.Biome) *ebiten.Image {
+	if b == nil {
+		return a.Frame(Ground)
+	}
+	if frame := a.frames[b.ID]; frame != nil {
+		return frame
+	}
+	r, g, bl := b.RGB()
+	return a.placeholder(b.ID, color.RGBA{r, g, bl, 255})
+}
+
+// Theme returns the frame drawn over the ground of a place with theme, or
+// nil if the atlas doesn't say how to draw it.
+func (a *Atlas) Theme(theme string) *ebiten.Image {
+	frame, ok := a.themes[theme]
+	if !ok {
+		return nil
+	}
+	return a.Frame(frame)
+}
+
+// Item returns the frame for an item of category.
+func (a *Atlas) Item(category string) *ebiten.Image {
+	if frame := a.frames[category]; frame != nil {
+		return frame
+	}
+	return a.Frame(AnyItem)
+}
@@ -1 +1,11 @@
 package types
+
+// Rect is an area of the screen, in pixels
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Contains reports whether the point x, y lies inside the rectangle
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
@@ -0,0 +1,154 @@
+package lib
+
+import (
+	"GentleWanderings/lib/content"
+	"fmt"
+	"strings"
+)
+
+// Action kinds
+const (
+	ActionExplore = "explore" // head somewhere new along one of the paths offered
+	ActionMove    = "move"    // walk back onto an explored tile
+	ActionTravel  = "travel"  // fast travel along a route
+	ActionDraw    = "draw"    // turn over a prompt card, if one turns up
+	ActionAnswer  = "answer"  // pick an answer to the card drawn
+	ActionWrite   = "write"   // write in the journal
+)
+
+// Action is one step of a journey. Every change to a journey is made by
+// applying an action, so replaying its log from the seed rebuilds it
+// exactly. Drawing a prompt card is an action too, although the engine
+// rather than the player takes it, because it uses up random numbers.
+type Action struct {
+	Kind        string   `json:"kind"`
+	Direction   string   `json:"direction,omitempty"`   // explore and move
+	Theme       string   `json:"theme,omitempty"`       // explore: the path taken
+	Description string   `json:"description,omitempty"` // explore
+	Choice      int      `json:"choice,omitempty"`      // answer, numbered from 0
+	Route       [][2]int `json:"route,omitempty"`       // travel: the tiles walked, from where it starts
+	Text        string   `json:"text,omitempty"`        // write
+	Prompt      string   `json:"prompt,omitempty"`      // write: the journaling prompt answered
+}
+
+// String describes the action in a few words, e.g. "explore north: Mossy Stones".
+func (a Action) String() string {
+	switch a.Kind {
+	case ActionExplore:
+		return fmt.Sprintf("explore %s: %s", strings.ToLower(a.Direction), a.Theme)
+	case ActionMove:
+		return "move " + strings.ToLower(a.Direction)
+	case ActionTravel:
+		if len(a.Route) == 0 {
+			return "travel"
+		}
+		end := a.Route[len(a.Route)-1]
+		return fmt.Sprintf("travel to %d,%d", end[0], end[1])
+	case ActionAnswer:
+		return fmt.Sprintf("answer %d", a.Choice+1)
+	case ActionWrite:
+		return "write"
+	}
+	return a.Kind
+}
+
+// Outcome is what applying an action turned up.
+type Outcome struct {
+	Item   *Item   // found exploring or answering a card
+	Prompt *Prompt // the card drawn, if any
+	Text   string  // the journal text of an answer
+}
+
+// ActionLog is a journey's actions in order, with the seed its world is
+// generated from: everything needed to play it again.
+type ActionLog struct {
+	Seed    int64    `json:"seed"`
+	Actions []Action `json:"actions"`
+}
+
+// Log returns the journey's action log, or nil if it was loaded from a save
+// made before journeys were logged and so can't be replayed.
+func (g *Game) Log() *ActionLog {
+	if g.log == nil {
+		return nil
+	}
+	return &ActionLog{Seed: g.log.Seed, Actions: append([]Action{}, g.log.Actions...)}
+}
+
+// Apply takes one step of the journey and records it in the action log and
+// the undo history. Actions that fail change nothing and aren't recorded.
+func (g *Game) Apply(a Action) (*Outcome, error) {
+	// Explorations can be undone, so note how things stood before
+	var before *snapshot
+	if a.Kind == ActionExplore {
+		before = g.snapshot()
+	}
+
+	out := &Outcome{}
+	var err error
+	switch a.Kind {
+	case ActionExplore:
+		var dir Direction
+		if dir, err = DirectionNamed(a.Direction); err == nil {
+			out.Item, err = g.explore(dir, LocationOption{Theme: a.Theme, Description: a.Description})
+		}
+	case ActionMove:
+		var dir Direction
+		if dir, err = DirectionNamed(a.Direction); err == nil {
+			err = g.move(dir)
+		}
+	case ActionTravel:
+		path := []*Tile{}
+		for _, at := range a.Route {
+			tile := g.GetTile(at[0], at[1])
+			if tile == nil {
+				return nil, fmt.Errorf("the route passes through unexplored land at (%d, %d)", at[0], at[1])
+			}
+			path = append(path, tile)
+		}
+		err = g.travel(path)
+	case ActionDraw:
+		out.Prompt = g.drawPrompt()
+	case ActionAnswer:
+		out.Text, out.Item, err = g.resolvePrompt(a.Choice)
+	case ActionWrite:
+		err = g.write(a.Text, a.Prompt)
+	default:
+		err = fmt.Errorf("unknown action %q", a.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A card left unanswered is put away once the player moves on
+	switch a.Kind {
+	case ActionExplore, ActionMove, ActionTravel:
+		g.prompt = nil
+	}
+	if g.log != nil {
+		g.log.Actions = append(g.log.Actions, a)
+	}
+	g.remember(a, before)
+	return out, nil
+}
+
+// Replay plays log from its seed with the given content, calling step after
+// each action with the journey so far. It stops at the first action that
+// fails, or the first error step returns.
+func Replay(log *ActionLog, pack *content.Pack, step func(i int, a Action, g *Game) error) (*Game, error) {
+	if pack == nil {
+		pack = content.Default()
+	}
+	g := NewGameWithContent(log.Seed, pack)
+	for i, a := range log.Actions {
+		if _, err := g.Apply(a); err != nil {
+			return g, fmt.Errorf("step %d (%s): %w", i+1, a, err)
+		}
+		if step != nil {
+			if err := step(i, a, g); err != nil {
+				return g, err
+			}
+		}
+	}
+	return g, nil
+}
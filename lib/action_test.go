@@ -0,0 +1,92 @@
+package lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// journey plays a little of everything from seed: exploring, answering a
+// card when one turns up, walking back, fast travel and writing.
+func journey(t *testing.T, seed int64) *Game {
+	t.Helper()
+	g := NewGameWithSeed(seed)
+	for _, name := range []string{"North", "East", "East", "South"} {
+		wander(t, g, name)
+		if p := g.DrawPrompt(); p != nil {
+			if _, _, err := g.ResolvePrompt(p, len(p.Choices)-1); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := g.Move(Directions[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Travel(g.FindPath(0, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Write("Home again.", g.Reflection()); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// sameJourney reports how got differs from want, if it does.
+func sameJourney(t *testing.T, got, want *Game) {
+	t.Helper()
+	if !reflect.DeepEqual(got.Map, want.Map) {
+		t.Errorf("map differs:\n got %v\nwant %v", got.Map, want.Map)
+	}
+	if !reflect.DeepEqual(got.JournalLog, want.JournalLog) {
+		t.Errorf("journal differs:\n got %v\nwant %v", got.JournalLog, want.JournalLog)
+	}
+	if !reflect.DeepEqual(got.Inventory, want.Inventory) {
+		t.Errorf("inventory differs:\n got %v\nwant %v", got.Inventory, want.Inventory)
+	}
+	if got.CurrentX != want.CurrentX || got.CurrentY != want.CurrentY || got.TurnCount != want.TurnCount {
+		t.Errorf("at (%d, %d) on day %d, want (%d, %d) on day %d",
+			got.CurrentX, got.CurrentY, got.TurnCount, want.CurrentX, want.CurrentY, want.TurnCount)
+	}
+	if got.source.draws != want.source.draws {
+		t.Errorf("generator drew %d values, want %d", got.source.draws, want.source.draws)
+	}
+	if !reflect.DeepEqual(got.Log(), want.Log()) {
+		t.Errorf("action log differs:\n got %v\nwant %v", got.Log(), want.Log())
+	}
+}
+
+func TestReplayMatchesLiveGame(t *testing.T) {
+	for _, seed := range []int64{1, 7, 42} {
+		live := journey(t, seed)
+
+		steps := 0
+		replayed, err := Replay(live.Log(), nil, func(i int, a Action, g *Game) error {
+			if i != steps {
+				t.Errorf("step %d reported as %d", steps, i)
+			}
+			steps++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if steps != len(live.Log().Actions) {
+			t.Errorf("seed %d: %d steps reported, want %d", seed, steps, len(live.Log().Actions))
+		}
+		sameJourney(t, replayed, live)
+
+		// A saved journey replays the same
+		loaded := roundTrip(t, live)
+		replayed, err = Replay(loaded.Log(), nil, nil)
+		if err != nil {
+			t.Fatalf("seed %d after loading: %v", seed, err)
+		}
+		sameJourney(t, replayed, loaded)
+	}
+}
+
+func TestReplayStopsAtBadAction(t *testing.T) {
+	log := &ActionLog{Seed: 1, Actions: []Action{{Kind: ActionMove, Direction: "North"}}}
+	if _, err := Replay(log, nil, nil); err == nil {
+		t.Error("replayed a move onto unexplored land")
+	}
+}
@@ -0,0 +1,74 @@
+package lib
+
+import (
+	"GentleWanderings/lib/content"
+	"math"
+)
+
+// biomeCellSize is the rough width, in tiles, of a biome region.
+const biomeCellSize = 6
+
+// BiomeAt returns the biome covering (x, y), or nil if the content defines none.
+//
+// Regions come from cellular noise: the plane is split into cells, each cell
+// gets a jittered centre point and a biome picked from the seed, and every
+// tile belongs to the nearest centre. This gives irregular regions that look
+// the same however the player reaches them.
+func (g *Game) BiomeAt(x, y int) *content.Biome {
+	biomes := g.content.Biomes
+	if len(biomes) == 0 {
+		return nil
+	}
+
+	cx := floorDiv(x, biomeCellSize)
+	cy := floorDiv(y, biomeCellSize)
+
+	var nearest uint64
+	best := math.Inf(1)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			h := g.tileHash(cx+dx, cy+dy, "biome")
+			// Jitter the centre anywhere inside its cell
+			px := float64((cx+dx)*biomeCellSize) + float64(h&0xffff)/0x10000*biomeCellSize
+			py := float64((cy+dy)*biomeCellSize) + float64((h>>16)&0xffff)/0x10000*biomeCellSize
+			dist := (float64(x)-px)*(float64(x)-px) + (float64(y)-py)*(float64(y)-py)
+			if dist < best {
+				best = dist
+				nearest = h
+			}
+		}
+	}
+
+	return &biomes[(nearest>>32)%uint64(len(biomes))]
+}
+
+// TileBiome returns the biome a tile was generated in, or nil if it has none.
+func (g *Game) TileBiome(tile *Tile) *content.Biome {
+	return g.content.Biome(tile.Biome)
+}
+
+// FoundBiomes lists the biomes of every explored tile, in content order.
+func (g *Game) FoundBiomes() []*content.Biome {
+	seen := map[string]bool{}
+	for _, tile := range g.Map {
+		seen[tile.Biome] = true
+	}
+
+	found := []*content.Biome{}
+	for i := range g.content.Biomes {
+		if biome := &g.content.Biomes[i]; seen[biome.ID] {
+			found = append(found, biome)
+		}
+	}
+	return found
+}
+
+// floorDiv divides rounding towards negative infinity, so cells line up
+// across the origin.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
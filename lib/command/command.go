@@ -0,0 +1,365 @@
+package command
+
+import (
+	"GentleWanderings/lib"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+The command registry turns a line the player typed into a call. Each command
+declares its names, the arguments it takes and its help text, so parsing,
+validation, "help" and suggestions for typos all come from one place and
+every front end reads commands the same way.
+*/
+
+// Kinds of argument
+const (
+	Word      = iota // a single word, optionally limited to Choices
+	Number           // a whole number
+	Direction        // north, south, east or west, by name, first letter or 1-4
+	Text             // the rest of the line
+)
+
+// Directions are the names a Direction argument accepts, in the order the
+// numbers 1-4 pick them.
+var Directions = directionNames()
+
+func directionNames() []string {
+	names := []string{}
+	for _, dir := range lib.Directions {
+		names = append(names, strings.ToLower(dir.Name))
+	}
+	return names
+}
+
+// Arg describes one argument a command takes.
+type Arg struct {
+	Name     string
+	Kind     int
+	Optional bool
+	Choices  []string // the words a Word argument may be, if limited
+}
+
+// Args are the parsed arguments of a command, by name. Directions are given
+// by their full name and missing optional arguments are "".
+type Args map[string]string
+
+// Int returns a Number argument, or 0 if it was left out.
+func (a Args) Int(name string) int {
+	n, _ := strconv.Atoi(a[name])
+	return n
+}
+
+// Command is something the player can type.
+type Command struct {
+	Name    string
+	Aliases []string
+	Args    []Arg
+	Summary string // one line for the command list
+	Help    string // more detail for "help <command>"
+	Run     func(args Args) error
+}
+
+// Usage shows how to type the command, e.g. "go <direction> [option]".
+func (c *Command) Usage() string {
+	parts := []string{c.Name}
+	for _, arg := range c.Args {
+		parts = append(parts, arg.usage())
+	}
+	return strings.Join(parts, " ")
+}
+
+// usage shows the argument as "<name>", or "[name]" if it is optional.
+func (a Arg) usage() string {
+	name := a.Name
+	if len(a.Choices) > 0 {
+		name = strings.Join(a.Choices, "|")
+	}
+	if a.Optional {
+		return "[" + name + "]"
+	}
+	return "<" + name + ">"
+}
+
+// UnknownError is returned for input that names no command.
+type UnknownError struct {
+	Input      string
+	Suggestion string // the closest command, if any is close
+}
+
+func (e *UnknownError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("I don't know how to %q. Did you mean %q? Type help to see every command.", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("I don't know how to %q. Type help to see every command.", e.Input)
+}
+
+// DirectionError is returned for a word that names no direction.
+type DirectionError struct {
+	Input      string
+	Suggestion string // the closest direction, if any is close
+}
+
+func (e *DirectionError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("unknown direction %q. Did you mean %q? Try north, south, east or west", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("unknown direction %q; try north, south, east or west", e.Input)
+}
+
+// UsageError is returned when a command's arguments don't fit.
+type UsageError struct {
+	Command *Command
+	Msg     string
+	Err     error // what was wrong with an argument, if that was the trouble
+}
+
+func (e *UsageError) Error() string {
+	return fmt.Sprintf("%s (usage: %s)", e.Msg, e.Command.Usage())
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// Registry holds every command the player can type.
+type Registry struct {
+	commands []*Command
+	names    map[string]*Command
+	out      io.Writer
+}
+
+// NewRegistry creates a registry that already knows "help", which it writes
+// to out.
+func NewRegistry(out io.Writer) *Registry {
+	r := &Registry{names: map[string]*Command{}, out: out}
+	r.Add(&Command{
+		Name:    "help",
+		Aliases: []string{"?", "h"},
+		Args:    []Arg{{Name: "command", Kind: Word, Optional: true}},
+		Summary: "List every command, or explain one",
+		Run: func(args Args) error {
+			return r.help(args["command"])
+		},
+	})
+	return r
+}
+
+// Add registers a command. Names and aliases must be unique.
+func (r *Registry) Add(cmd *Command) {
+	for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+		if _, ok := r.names[name]; ok {
+			panic(fmt.Sprintf("command: %q is registered twice", name))
+		}
+		r.names[name] = cmd
+	}
+	r.commands = append(r.commands, cmd)
+}
+
+// Lookup finds a command by name or alias.
+func (r *Registry) Lookup(name string) *Command {
+	return r.names[strings.ToLower(name)]
+}
+
+// Commands lists every command in the order they were added.
+func (r *Registry) Commands() []*Command {
+	return r.commands
+}
+
+// Parse reads a line into a command and its arguments.
+func (r *Registry) Parse(line string) (*Command, Args, error) {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return nil, nil, &UnknownError{}
+	}
+
+	cmd := r.Lookup(words[0])
+	if cmd == nil {
+		return nil, nil, &UnknownError{Input: words[0], Suggestion: r.Suggest(words[0])}
+	}
+
+	args := Args{}
+	rest := words[1:]
+	for _, arg := range cmd.Args {
+		if len(rest) == 0 {
+			if !arg.Optional {
+				return nil, nil, &UsageError{Command: cmd, Msg: fmt.Sprintf("%s is missing %s", cmd.Name, arg.usage())}
+			}
+			args[arg.Name] = ""
+			continue
+		}
+
+		if arg.Kind == Text {
+			args[arg.Name] = textAfter(line, len(words)-len(rest))
+			rest = nil
+			break
+		}
+
+		value, err := parseArg(arg, rest[0])
+		if err != nil {
+			return nil, nil, &UsageError{Command: cmd, Msg: err.Error(), Err: err}
+		}
+		args[arg.Name] = value
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		return nil, nil, &UsageError{Command: cmd, Msg: fmt.Sprintf("%s doesn't expect %q", cmd.Name, strings.Join(rest, " "))}
+	}
+	return cmd, args, nil
+}
+
+// Run parses a line and runs the command it names.
+func (r *Registry) Run(line string) error {
+	cmd, args, err := r.Parse(line)
+	if err != nil {
+		return err
+	}
+	return cmd.Run(args)
+}
+
+func parseArg(arg Arg, word string) (string, error) {
+	lower := strings.ToLower(word)
+	switch arg.Kind {
+	case Number:
+		if _, err := strconv.Atoi(word); err != nil {
+			return "", fmt.Errorf("%s should be a number, not %q", arg.Name, word)
+		}
+	case Direction:
+		if n, err := strconv.Atoi(word); err == nil && n >= 1 && n <= len(Directions) {
+			return Directions[n-1], nil
+		}
+		if dir, err := lib.DirectionNamed(word); err == nil {
+			return strings.ToLower(dir.Name), nil
+		}
+		return "", &DirectionError{Input: word, Suggestion: SuggestDirection(word)}
+	case Word:
+		if len(arg.Choices) == 0 {
+			break
+		}
+		for _, choice := range arg.Choices {
+			if lower == choice {
+				return choice, nil
+			}
+		}
+		return "", fmt.Errorf("%s should be one of %s", arg.Name, strings.Join(arg.Choices, ", "))
+	}
+	return word, nil
+}
+
+// textAfter returns line with its first n words removed, keeping the
+// spacing and case of what is left.
+func textAfter(line string, n int) string {
+	line = strings.TrimSpace(line)
+	for i := 0; i < n; i++ {
+		end := strings.IndexAny(line, " \t")
+		if end < 0 {
+			return ""
+		}
+		line = strings.TrimSpace(line[end:])
+	}
+	return line
+}
+
+// Suggest returns the command name closest to input, or "" if nothing is
+// close enough to be a likely typo.
+func (r *Registry) Suggest(input string) string {
+	input = strings.ToLower(input)
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// A unique prefix is the best guess
+	prefixed := ""
+	for _, name := range names {
+		if strings.HasPrefix(name, input) {
+			if prefixed != "" && r.names[prefixed] != r.names[name] {
+				prefixed = ""
+				break
+			}
+			prefixed = name
+		}
+	}
+	if prefixed != "" {
+		return r.names[prefixed].Name
+	}
+
+	best, bestDist := "", len(input)/2+1
+	for _, name := range names {
+		if len(name) < 2 {
+			continue
+		}
+		if d := distance(input, name); d < bestDist {
+			best, bestDist = r.names[name].Name, d
+		}
+	}
+	return best
+}
+
+// SuggestDirection returns the direction closest to input, or "" if none is
+// close enough to be a likely typo.
+func SuggestDirection(input string) string {
+	input = strings.ToLower(input)
+	best, bestDist := "", len(input)/2+1
+	for _, dir := range Directions {
+		if d := distance(input, dir); d < bestDist {
+			best, bestDist = dir, d
+		}
+	}
+	return best
+}
+
+// distance is the Levenshtein edit distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// help writes the list of commands, or the details of one.
+func (r *Registry) help(name string) error {
+	if name == "" {
+		width := 0
+		for _, cmd := range r.commands {
+			width = max(width, len(cmd.Usage()))
+		}
+		fmt.Fprintln(r.out, "\nCommands:")
+		for _, cmd := range r.commands {
+			fmt.Fprintf(r.out, "  %-*s  %s\n", width, cmd.Usage(), cmd.Summary)
+		}
+		fmt.Fprintln(r.out, "\nType help <command> to learn more about one.")
+		return nil
+	}
+
+	cmd := r.Lookup(name)
+	if cmd == nil {
+		return &UnknownError{Input: name, Suggestion: r.Suggest(name)}
+	}
+	fmt.Fprintf(r.out, "\n%s\n  %s\n", cmd.Usage(), cmd.Summary)
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(r.out, "  Also: %s\n", strings.Join(cmd.Aliases, ", "))
+	}
+	if cmd.Help != "" {
+		fmt.Fprintf(r.out, "\n%s\n", cmd.Help)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestParseDirection(t *testing.T) {
+	r := NewRegistry(io.Discard)
+	r.Add(&Command{Name: "go", Args: []Arg{{Name: "direction", Kind: Direction}}})
+
+	for input, want := range map[string]string{"go north": "north", "go E": "east", "go 4": "west"} {
+		_, args, err := r.Parse(input)
+		if err != nil || args["direction"] != want {
+			t.Errorf("%q parsed to %q, %v, want %q", input, args["direction"], err, want)
+		}
+	}
+
+	for input, want := range map[string]string{"go nrth": "north", "go wset": "west", "go sideways": ""} {
+		_, _, err := r.Parse(input)
+		var dirErr *DirectionError
+		if !errors.As(err, &dirErr) {
+			t.Errorf("%q gave %v, want an unknown direction", input, err)
+			continue
+		}
+		if dirErr.Suggestion != want {
+			t.Errorf("%q suggested %q, want %q", input, dirErr.Suggestion, want)
+		}
+	}
+}
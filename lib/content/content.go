@@ -0,0 +1,462 @@
+package content
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/*
+Content packs hold all of the flavour text the world is generated from, so
+writers can add themes and items without touching Go code. The packs in
+packs/ are embedded as the defaults; packs loaded from a directory are merged
+on top of them.
+*/
+
+//go:embed packs/*.json
+var embedded embed.FS
+
+// Pack holds the flavour text used to generate locations, discoveries and items.
+type Pack struct {
+	Name        string      `json:"name"`
+	Themes      []string    `json:"themes"`
+	Descriptors Descriptors `json:"descriptors"`
+	Discoveries []string    `json:"discoveries"`
+	Returns     []string    `json:"returns"`
+	Reflections []string    `json:"reflections"`
+	ItemChance  *float64    `json:"item_chance,omitempty"`
+	Categories  []Category  `json:"categories"`
+	Biomes      []Biome     `json:"biomes"`
+
+	Tables       map[string][]Entry `json:"tables"`
+	Prompts      []Prompt           `json:"prompts"`
+	PromptChance *float64           `json:"prompt_chance,omitempty"`
+}
+
+// Descriptors are the word tables a location description is assembled from.
+type Descriptors struct {
+	Adjectives []string `json:"adjectives"`
+	Details    []string `json:"details"`
+	Feelings   []string `json:"feelings"`
+}
+
+// Category is a kind of item, such as keepsakes or treasures.
+type Category struct {
+	ID           string   `json:"id"`
+	Label        string   `json:"label"`
+	Names        []string `json:"names"`
+	Descriptions []string `json:"descriptions"`
+}
+
+// Biome is a region of the world with its own themes, descriptions and items.
+// Empty descriptor tables fall back to the pack's shared ones, and categories
+// missing from ItemWeights get a weight of 1.
+type Biome struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Color       string         `json:"color"` // #rrggbb, used to tint maps
+	Themes      []string       `json:"themes"`
+	Descriptors Descriptors    `json:"descriptors"`
+	ItemWeights map[string]int `json:"item_weights"`
+}
+
+// RGB returns the biome colour as its red, green and blue components.
+func (b *Biome) RGB() (r, g, bl uint8) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(b.Color, "#"), 16, 32)
+	if err != nil {
+		return 128, 128, 128
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v)
+}
+
+// Weight returns how likely an item category is to be found in the biome.
+func (b *Biome) Weight(category string) int {
+	if w, ok := b.ItemWeights[category]; ok {
+		return w
+	}
+	return 1
+}
+
+// Error points at the file and key of a problem in a content pack.
+type Error struct {
+	File string
+	Key  string
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s: %s", e.File, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.File, e.Key, e.Msg)
+}
+
+// hexColor matches colours written as #rrggbb.
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// mergedFile names the merged result in errors that no single file caused.
+const mergedFile = "merged content"
+
+// Chance returns the probability of finding an item at a new location.
+func (p *Pack) Chance() float64 {
+	if p.ItemChance == nil {
+		return 0
+	}
+	return *p.ItemChance
+}
+
+// Biome looks up a biome by its id.
+func (p *Pack) Biome(id string) *Biome {
+	for i := range p.Biomes {
+		if p.Biomes[i].ID == id {
+			return &p.Biomes[i]
+		}
+	}
+	return nil
+}
+
+// Category looks up an item category by its id.
+func (p *Pack) Category(id string) *Category {
+	for i := range p.Categories {
+		if p.Categories[i].ID == id {
+			return &p.Categories[i]
+		}
+	}
+	return nil
+}
+
+var defaultPack = sync.OnceValue(func() *Pack {
+	packs, err := readPacks(embedded, "packs", "packs")
+	if err != nil {
+		panic(err)
+	}
+	pack, err := Merge(packs...)
+	if err != nil {
+		panic(err)
+	}
+	return pack
+})
+
+// Default returns the built-in content shipped with the game.
+func Default() *Pack {
+	return defaultPack()
+}
+
+// Load merges every pack in dir, in file name order, on top of the built-in content.
+func Load(dir string) (*Pack, error) {
+	base, err := readPacks(embedded, "packs", "packs")
+	if err != nil {
+		return nil, err
+	}
+	extra, err := readPacks(os.DirFS(dir), ".", dir)
+	if err != nil {
+		return nil, err
+	}
+	return Merge(append(base, extra...)...)
+}
+
+// readPacks parses and checks every .json file in dir, reporting all problems
+// at once. Files are named in errors relative to prefix.
+func readPacks(fsys fs.FS, dir, prefix string) ([]*Pack, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	packs := []*Pack{}
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		pack, err := Parse(filepath.Join(prefix, entry.Name()), data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		packs = append(packs, pack)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return packs, nil
+}
+
+// Parse decodes a single pack and checks each entry in it. The file name is
+// only used in error messages.
+func Parse(file string, data []byte) (*Pack, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var pack Pack
+	if err := dec.Decode(&pack); err != nil {
+		return nil, decodeError(file, data, err)
+	}
+	if err := pack.check(file); err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
+
+// decodeError turns a JSON decoding error into one that names the offending key.
+func decodeError(file string, data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line := 1 + bytes.Count(data[:syntaxErr.Offset], []byte("\n"))
+		return &Error{File: file, Msg: fmt.Sprintf("line %d: %v", line, syntaxErr)}
+	case errors.As(err, &typeErr):
+		return &Error{File: file, Key: typeErr.Field, Msg: fmt.Sprintf("expected %s but found %s", typeErr.Type, typeErr.Value)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		key := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return &Error{File: file, Key: key, Msg: "unknown key"}
+	}
+	return &Error{File: file, Msg: err.Error()}
+}
+
+// check validates the entries of a single pack. A pack may leave sections out
+// since it can be merged with others.
+func (p *Pack) check(file string) error {
+	var errs []error
+	fail := func(key, msg string) {
+		errs = append(errs, &Error{File: file, Key: key, Msg: msg})
+	}
+
+	nonEmpty := func(key string, list []string) {
+		for i, s := range list {
+			if strings.TrimSpace(s) == "" {
+				fail(fmt.Sprintf("%s[%d]", key, i), "must not be empty")
+			}
+		}
+	}
+	templates := func(key string, list []string) {
+		for i, s := range list {
+			if strings.Count(s, "%s") != 1 || strings.Count(s, "%") != 1 {
+				fail(fmt.Sprintf("%s[%d]", key, i), "must contain exactly one %s where the place name goes")
+			}
+		}
+	}
+
+	nonEmpty("themes", p.Themes)
+	nonEmpty("descriptors.adjectives", p.Descriptors.Adjectives)
+	nonEmpty("descriptors.details", p.Descriptors.Details)
+	nonEmpty("descriptors.feelings", p.Descriptors.Feelings)
+	templates("discoveries", p.Discoveries)
+	templates("returns", p.Returns)
+	nonEmpty("reflections", p.Reflections)
+
+	if p.ItemChance != nil && (*p.ItemChance < 0 || *p.ItemChance > 1) {
+		fail("item_chance", "must be between 0 and 1")
+	}
+
+	seen := map[string]bool{}
+	for i, cat := range p.Categories {
+		key := fmt.Sprintf("categories[%d]", i)
+		if strings.TrimSpace(cat.ID) == "" {
+			fail(key+".id", "must not be empty")
+		} else if seen[cat.ID] {
+			fail(key+".id", fmt.Sprintf("%q is defined twice", cat.ID))
+		}
+		seen[cat.ID] = true
+		nonEmpty(key+".names", cat.Names)
+		nonEmpty(key+".descriptions", cat.Descriptions)
+	}
+
+	p.checkOracle(fail)
+
+	seen = map[string]bool{}
+	for i, biome := range p.Biomes {
+		key := fmt.Sprintf("biomes[%d]", i)
+		if strings.TrimSpace(biome.ID) == "" {
+			fail(key+".id", "must not be empty")
+		} else if seen[biome.ID] {
+			fail(key+".id", fmt.Sprintf("%q is defined twice", biome.ID))
+		}
+		seen[biome.ID] = true
+		if biome.Color != "" && !hexColor.MatchString(biome.Color) {
+			fail(key+".color", "must look like #3a7d44")
+		}
+		nonEmpty(key+".themes", biome.Themes)
+		nonEmpty(key+".descriptors.adjectives", biome.Descriptors.Adjectives)
+		nonEmpty(key+".descriptors.details", biome.Descriptors.Details)
+		nonEmpty(key+".descriptors.feelings", biome.Descriptors.Feelings)
+		for cat, weight := range biome.ItemWeights {
+			if weight < 0 {
+				fail(fmt.Sprintf("%s.item_weights.%s", key, cat), "must not be negative")
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Merge combines packs in order. Lists are appended (skipping duplicates),
+// categories with the same id are combined, and later packs override the
+// item chance and category labels. The result must be complete enough to
+// generate a world.
+func Merge(packs ...*Pack) (*Pack, error) {
+	merged := &Pack{}
+	names := []string{}
+
+	for _, p := range packs {
+		if p.Name != "" {
+			names = append(names, p.Name)
+		}
+		merged.Themes = appendUnique(merged.Themes, p.Themes)
+		merged.Descriptors.Adjectives = appendUnique(merged.Descriptors.Adjectives, p.Descriptors.Adjectives)
+		merged.Descriptors.Details = appendUnique(merged.Descriptors.Details, p.Descriptors.Details)
+		merged.Descriptors.Feelings = appendUnique(merged.Descriptors.Feelings, p.Descriptors.Feelings)
+		merged.Discoveries = appendUnique(merged.Discoveries, p.Discoveries)
+		merged.Returns = appendUnique(merged.Returns, p.Returns)
+		merged.Reflections = appendUnique(merged.Reflections, p.Reflections)
+		if p.ItemChance != nil {
+			chance := *p.ItemChance
+			merged.ItemChance = &chance
+		}
+
+		for _, cat := range p.Categories {
+			existing := merged.Category(cat.ID)
+			if existing == nil {
+				merged.Categories = append(merged.Categories, Category{ID: cat.ID})
+				existing = &merged.Categories[len(merged.Categories)-1]
+			}
+			if cat.Label != "" {
+				existing.Label = cat.Label
+			}
+			existing.Names = appendUnique(existing.Names, cat.Names)
+			existing.Descriptions = appendUnique(existing.Descriptions, cat.Descriptions)
+		}
+
+		for _, biome := range p.Biomes {
+			existing := merged.Biome(biome.ID)
+			if existing == nil {
+				merged.Biomes = append(merged.Biomes, Biome{ID: biome.ID, ItemWeights: map[string]int{}})
+				existing = &merged.Biomes[len(merged.Biomes)-1]
+			}
+			if biome.Name != "" {
+				existing.Name = biome.Name
+			}
+			if biome.Color != "" {
+				existing.Color = biome.Color
+			}
+			existing.Themes = appendUnique(existing.Themes, biome.Themes)
+			existing.Descriptors.Adjectives = appendUnique(existing.Descriptors.Adjectives, biome.Descriptors.Adjectives)
+			existing.Descriptors.Details = appendUnique(existing.Descriptors.Details, biome.Descriptors.Details)
+			existing.Descriptors.Feelings = appendUnique(existing.Descriptors.Feelings, biome.Descriptors.Feelings)
+			for cat, weight := range biome.ItemWeights {
+				existing.ItemWeights[cat] = weight
+			}
+		}
+
+		mergeOracle(merged, p)
+	}
+	merged.Name = strings.Join(names, " + ")
+
+	if err := merged.complete(); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// complete checks that a merged pack has everything the generators need.
+func (p *Pack) complete() error {
+	var errs []error
+	fail := func(key, msg string) {
+		errs = append(errs, &Error{File: mergedFile, Key: key, Msg: msg})
+	}
+
+	if len(p.Themes) < 3 {
+		fail("themes", "needs at least 3 themes so three paths can be offered")
+	}
+	for key, list := range map[string][]string{
+		"descriptors.adjectives": p.Descriptors.Adjectives,
+		"descriptors.details":    p.Descriptors.Details,
+		"descriptors.feelings":   p.Descriptors.Feelings,
+		"discoveries":            p.Discoveries,
+		"returns":                p.Returns,
+	} {
+		if len(list) == 0 {
+			fail(key, "needs at least one entry")
+		}
+	}
+	if len(p.Categories) == 0 {
+		fail("categories", "needs at least one item category")
+	}
+	for _, cat := range p.Categories {
+		key := fmt.Sprintf("categories[%s]", cat.ID)
+		if cat.Label == "" {
+			fail(key+".label", "must not be empty")
+		}
+		if len(cat.Names) == 0 {
+			fail(key+".names", "needs at least one entry")
+		}
+		if len(cat.Descriptions) == 0 {
+			fail(key+".descriptions", "needs at least one entry")
+		}
+	}
+
+	for _, biome := range p.Biomes {
+		key := fmt.Sprintf("biomes[%s]", biome.ID)
+		if biome.Name == "" {
+			fail(key+".name", "must not be empty")
+		}
+		if biome.Color == "" {
+			fail(key+".color", "must not be empty")
+		}
+		if len(biome.Themes) < 3 {
+			fail(key+".themes", "needs at least 3 themes so three paths can be offered")
+		}
+		for cat := range biome.ItemWeights {
+			if p.Category(cat) == nil {
+				fail(fmt.Sprintf("%s.item_weights.%s", key, cat), "is not a known item category")
+			}
+		}
+		total := 0
+		for _, cat := range p.Categories {
+			total += biome.Weight(cat.ID)
+		}
+		if total == 0 && len(p.Categories) > 0 {
+			fail(key+".item_weights", "must leave at least one item category possible")
+		}
+	}
+
+	p.completeOracle(fail)
+
+	// Map iteration order is random, so keep the report stable
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+func appendUnique(list, extra []string) []string {
+	for _, s := range extra {
+		found := false
+		for _, existing := range list {
+			if existing == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, s)
+		}
+	}
+	return list
+}
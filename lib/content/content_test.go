@@ -0,0 +1,151 @@
+package content
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// withBase merges the built-in packs with extra, written as JSON.
+func withBase(t *testing.T, extra string) (*Pack, error) {
+	t.Helper()
+	base, err := readPacks(embedded, "packs", "packs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack, err := Parse("extra.json", []byte(extra))
+	if err != nil {
+		t.Fatalf("parsing: %v", err)
+	}
+	return Merge(append(base, pack)...)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"placeholder missing", `{"discoveries": ["A quiet place."]}`,
+			"x.json: discoveries[0]: must contain exactly one %s where the place name goes"},
+		{"placeholder twice", `{"discoveries": ["%s is here.", "%s and %s"]}`,
+			"x.json: discoveries[1]: must contain exactly one %s where the place name goes"},
+		{"bad colour", `{"biomes": [{"id": "fen", "color": "green"}]}`,
+			"x.json: biomes[0].color: must look like #3a7d44"},
+		{"type mismatch", `{"themes": "Grove"}`,
+			"x.json: themes: expected []string but found string"},
+		{"syntax error", "{\n  \"themes\": [\"Grove\",\n}",
+			"x.json: line 3: invalid character '}' looking for beginning of value"},
+		{"unknown key", `{"colour": "#3a7d44"}`,
+			"x.json: colour: unknown key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("x.json", []byte(tt.json))
+			if err == nil {
+				t.Fatal("parsed without an error")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("error is\n%s\nwant\n%s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	pack, err := withBase(t, `{
+		"name": "Extra",
+		"themes": ["Hollow Tree", "Lantern Hill"],
+		"categories": [{"id": "keepsake", "label": "Mementos", "names": ["Ribbon"]}],
+		"biomes": [{"id": "coast", "color": "#000080", "themes": ["Lantern Hill"]}]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := Default()
+
+	if pack.Name != base.Name+" + Extra" {
+		t.Errorf("named %q", pack.Name)
+	}
+
+	// Lists gain only what they lack
+	if got, want := pack.Themes, append(append([]string{}, base.Themes...), "Lantern Hill"); !reflect.DeepEqual(got, want) {
+		t.Errorf("themes are %v, want %v", got, want)
+	}
+
+	keepsake := pack.Category("keepsake")
+	if keepsake.Label != "Mementos" {
+		t.Errorf("keepsakes are labelled %q, want the later label", keepsake.Label)
+	}
+	if names := keepsake.Names; names[len(names)-1] != "Ribbon" || len(names) != len(base.Category("keepsake").Names)+1 {
+		t.Errorf("keepsake names are %v, want the built-in ones then Ribbon", names)
+	}
+	if got, want := keepsake.Descriptions, base.Category("keepsake").Descriptions; !reflect.DeepEqual(got, want) {
+		t.Errorf("keepsake descriptions changed to %v", got)
+	}
+
+	coast, baseCoast := pack.Biome("coast"), base.Biome("coast")
+	if coast.Name != baseCoast.Name || coast.Color != "#000080" {
+		t.Errorf("coast is %q coloured %s, want %q coloured #000080", coast.Name, coast.Color, baseCoast.Name)
+	}
+	if got, want := coast.Themes, append(append([]string{}, baseCoast.Themes...), "Lantern Hill"); !reflect.DeepEqual(got, want) {
+		t.Errorf("coast themes are %v, want %v", got, want)
+	}
+	if len(pack.Biomes) != len(base.Biomes) {
+		t.Errorf("%d biomes, want %d", len(pack.Biomes), len(base.Biomes))
+	}
+}
+
+func TestMergeRejectsIncompletePacks(t *testing.T) {
+	tests := []struct {
+		name  string
+		packs []*Pack
+		extra string
+		want  []string
+	}{
+		{
+			name:  "nothing to generate from",
+			packs: []*Pack{{Themes: []string{"Grove"}}},
+			want: []string{
+				"merged content: categories: needs at least one item category",
+				"merged content: descriptors.adjectives: needs at least one entry",
+				"merged content: discoveries: needs at least one entry",
+				"merged content: returns: needs at least one entry",
+				"merged content: themes: needs at least 3 themes so three paths can be offered",
+			},
+		},
+		{
+			name:  "new category without names",
+			extra: `{"categories": [{"id": "relic", "label": "Relics", "descriptions": ["Old."]}]}`,
+			want:  []string{"merged content: categories[relic].names: needs at least one entry"},
+		},
+		{
+			name:  "new biome without a colour",
+			extra: `{"biomes": [{"id": "fen", "name": "Fen", "themes": ["Reed", "Pool", "Mire"]}]}`,
+			want:  []string{"merged content: biomes[fen].color: must not be empty"},
+		},
+		{
+			name:  "weight for a missing category",
+			extra: `{"biomes": [{"id": "coast", "item_weights": {"relic": 2}}]}`,
+			want:  []string{"merged content: biomes[coast].item_weights.relic: is not a known item category"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.packs != nil {
+				_, err = Merge(tt.packs...)
+			} else {
+				_, err = withBase(t, tt.extra)
+			}
+			if err == nil {
+				t.Fatal("merged without an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error is\n%s\nwant it to mention\n%s", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package content
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/*
+Oracle tables are weighted lists of answers the game rolls on, and prompt
+cards are the "When you arrive, you notice..." moments built from them. Any
+text can roll on a table with {roll:name}; the answer may roll again.
+*/
+
+// RollRef matches a {roll:table} reference inside oracle text.
+var RollRef = regexp.MustCompile(`\{roll:([a-z0-9_-]+)\}`)
+
+// tableName matches the names a table may be given.
+var tableName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// Entry is one weighted answer on an oracle table.
+type Entry struct {
+	Text   string `json:"text"`
+	Weight int    `json:"weight,omitempty"` // defaults to 1
+}
+
+// Prompt is a card drawn on arrival, offering the player a few choices.
+type Prompt struct {
+	ID      string   `json:"id"`
+	Text    string   `json:"text"`
+	Biomes  []string `json:"biomes,omitempty"` // empty means anywhere
+	Choices []Choice `json:"choices"`
+}
+
+// Choice is one answer to a prompt card and what follows from it.
+type Choice struct {
+	Text    string `json:"text"`
+	Journal string `json:"journal"`           // written to the journal when chosen
+	Item    string `json:"item,omitempty"`    // item category granted, if any
+	Feature string `json:"feature,omitempty"` // feature added to the tile, if any
+}
+
+// Odds returns the entry's weight, treating an unset weight as 1.
+func (e Entry) Odds() int {
+	if e.Weight == 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+// PromptOdds returns the probability of drawing a prompt card on arrival.
+func (p *Pack) PromptOdds() float64 {
+	if p.PromptChance == nil {
+		return 0
+	}
+	return *p.PromptChance
+}
+
+// Prompt looks up a prompt card by its id.
+func (p *Pack) Prompt(id string) *Prompt {
+	for i := range p.Prompts {
+		if p.Prompts[i].ID == id {
+			return &p.Prompts[i]
+		}
+	}
+	return nil
+}
+
+// checkOracle validates the oracle tables and prompts of a single pack.
+func (p *Pack) checkOracle(fail func(key, msg string)) {
+	if p.PromptChance != nil && (*p.PromptChance < 0 || *p.PromptChance > 1) {
+		fail("prompt_chance", "must be between 0 and 1")
+	}
+
+	for name, entries := range p.Tables {
+		key := "tables." + name
+		if !tableName.MatchString(name) {
+			fail(key, "table names may only use a-z, 0-9, _ and -")
+		}
+		for i, entry := range entries {
+			if strings.TrimSpace(entry.Text) == "" {
+				fail(fmt.Sprintf("%s[%d].text", key, i), "must not be empty")
+			}
+			if entry.Weight < 0 {
+				fail(fmt.Sprintf("%s[%d].weight", key, i), "must not be negative")
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, prompt := range p.Prompts {
+		key := fmt.Sprintf("prompts[%d]", i)
+		if strings.TrimSpace(prompt.ID) == "" {
+			fail(key+".id", "must not be empty")
+		} else if seen[prompt.ID] {
+			fail(key+".id", fmt.Sprintf("%q is defined twice", prompt.ID))
+		}
+		seen[prompt.ID] = true
+		if strings.TrimSpace(prompt.Text) == "" {
+			fail(key+".text", "must not be empty")
+		}
+		if len(prompt.Choices) < 2 || len(prompt.Choices) > 4 {
+			fail(key+".choices", "must offer between 2 and 4 choices")
+		}
+		for j, choice := range prompt.Choices {
+			choiceKey := fmt.Sprintf("%s.choices[%d]", key, j)
+			if strings.TrimSpace(choice.Text) == "" {
+				fail(choiceKey+".text", "must not be empty")
+			}
+			if strings.TrimSpace(choice.Journal) == "" {
+				fail(choiceKey+".journal", "must not be empty")
+			}
+		}
+	}
+}
+
+// mergeOracle adds the tables and prompts of p to merged. Table entries are
+// appended and prompts with the same id are replaced.
+func mergeOracle(merged, p *Pack) {
+	if p.PromptChance != nil {
+		chance := *p.PromptChance
+		merged.PromptChance = &chance
+	}
+
+	for name, entries := range p.Tables {
+		if merged.Tables == nil {
+			merged.Tables = map[string][]Entry{}
+		}
+		merged.Tables[name] = append(merged.Tables[name], entries...)
+	}
+
+	for _, prompt := range p.Prompts {
+		if existing := merged.Prompt(prompt.ID); existing != nil {
+			*existing = prompt
+			continue
+		}
+		merged.Prompts = append(merged.Prompts, prompt)
+	}
+}
+
+// completeOracle checks that every table and category an oracle refers to
+// exists, and that tables never roll on themselves.
+func (p *Pack) completeOracle(fail func(key, msg string)) {
+	refs := func(key, text string) {
+		for _, match := range RollRef.FindAllStringSubmatch(text, -1) {
+			if _, ok := p.Tables[match[1]]; !ok {
+				fail(key, fmt.Sprintf("rolls on unknown table %q", match[1]))
+			}
+		}
+	}
+
+	for name, entries := range p.Tables {
+		if len(entries) == 0 {
+			fail("tables."+name, "needs at least one entry")
+		}
+		for i, entry := range entries {
+			refs(fmt.Sprintf("tables.%s[%d].text", name, i), entry.Text)
+		}
+		if p.rollsOn(name, name, map[string]bool{}) {
+			fail("tables."+name, "rolls on itself, which would never finish")
+		}
+	}
+
+	for _, prompt := range p.Prompts {
+		key := fmt.Sprintf("prompts[%s]", prompt.ID)
+		refs(key+".text", prompt.Text)
+		for _, biome := range prompt.Biomes {
+			if p.Biome(biome) == nil {
+				fail(key+".biomes", fmt.Sprintf("%q is not a known biome", biome))
+			}
+		}
+		for j, choice := range prompt.Choices {
+			choiceKey := fmt.Sprintf("%s.choices[%d]", key, j)
+			refs(choiceKey+".journal", choice.Journal)
+			refs(choiceKey+".feature", choice.Feature)
+			if choice.Item != "" && p.Category(choice.Item) == nil {
+				fail(choiceKey+".item", fmt.Sprintf("%q is not a known item category", choice.Item))
+			}
+		}
+	}
+}
+
+// rollsOn reports whether rolling on table can lead to rolling on target.
+func (p *Pack) rollsOn(table, target string, visited map[string]bool) bool {
+	if visited[table] {
+		return false
+	}
+	visited[table] = true
+
+	next := []string{}
+	for _, entry := range p.Tables[table] {
+		for _, match := range RollRef.FindAllStringSubmatch(entry.Text, -1) {
+			next = append(next, match[1])
+		}
+	}
+	sort.Strings(next)
+	for _, name := range next {
+		if name == target || p.rollsOn(name, target, visited) {
+			return true
+		}
+	}
+	return false
+}
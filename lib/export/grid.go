@@ -0,0 +1,31 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"strings"
+)
+
+// textMarks are the characters textMap draws each kind of map cell with.
+var textMarks = map[lib.MapCell]string{
+	lib.CellFog:      " ",
+	lib.CellFrontier: ".",
+	lib.CellExplored: "o",
+	lib.CellItem:     "+",
+	lib.CellRoute:    "o",
+	lib.CellPlayer:   "@",
+}
+
+// textMap draws the explored map with plain characters so it survives any
+// file format: @ is the player, + a tile with an item, o an explored tile
+// and . the unexplored edge.
+func textMap(g *lib.Game) []string {
+	lines := []string{}
+	for _, row := range g.MapGrid(nil).Cells {
+		var line strings.Builder
+		for _, cell := range row {
+			line.WriteString(" " + textMarks[cell])
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	return lines
+}
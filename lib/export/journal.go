@@ -0,0 +1,265 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// Journal export formats
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatText     = "txt"
+)
+
+// Formats lists every supported journal export format.
+var Formats = []string{FormatMarkdown, FormatHTML, FormatText}
+
+// journalDoc is the journal gathered into days, ready for any format.
+type journalDoc struct {
+	Title     string
+	Seed      int64
+	Days      int
+	Locations int
+	Map       []string
+	Grid      [][]gridCell
+	Entries   []dayEntries
+	Items     []lib.ItemGroup
+}
+
+// dayEntries is everything written on one day, at the place it began.
+type dayEntries struct {
+	Day     int
+	Place   string
+	X, Y    int
+	Entries []lib.JournalEntry
+}
+
+// gridCell is one square of the HTML map snapshot.
+type gridCell struct {
+	Mark  string
+	Color string
+	Title string
+}
+
+// Journal writes the day-by-day journal, the items found and a snapshot of
+// the map in the given format.
+func Journal(w io.Writer, g *lib.Game, format string) error {
+	doc := newJournalDoc(g)
+
+	switch format {
+	case FormatMarkdown:
+		return journalMarkdown(w, doc)
+	case FormatHTML:
+		return journalHTML.Execute(w, doc)
+	case FormatText:
+		return journalText(w, doc)
+	}
+	return fmt.Errorf("unknown journal format %q (choose from %s)", format, strings.Join(Formats, ", "))
+}
+
+func newJournalDoc(g *lib.Game) *journalDoc {
+	doc := &journalDoc{
+		Title:     "Gentle Wanderings Journal",
+		Seed:      g.Seed(),
+		Days:      g.TurnCount,
+		Locations: len(g.Map),
+		Map:       textMap(g),
+		Grid:      htmlGrid(g),
+	}
+
+	for _, entry := range g.JournalLog {
+		if len(doc.Entries) == 0 || doc.Entries[len(doc.Entries)-1].Day != entry.Day {
+			day := dayEntries{Day: entry.Day, X: entry.X, Y: entry.Y}
+			if tile := g.GetTile(entry.X, entry.Y); tile != nil {
+				day.Place = tile.Theme
+			}
+			doc.Entries = append(doc.Entries, day)
+		}
+		day := &doc.Entries[len(doc.Entries)-1]
+		day.Entries = append(day.Entries, entry)
+	}
+
+	doc.Items = g.InventoryGroups()
+	return doc
+}
+
+// htmlGrid builds the map snapshot as coloured cells, one per tile.
+func htmlGrid(g *lib.Game) [][]gridCell {
+	minX, maxX, minY, maxY := g.Bounds()
+
+	grid := [][]gridCell{}
+	for y := maxY; y >= minY; y-- {
+		row := []gridCell{}
+		for x := minX; x <= maxX; x++ {
+			tile := g.GetTile(x, y)
+			if tile == nil {
+				row = append(row, gridCell{})
+				continue
+			}
+
+			cell := gridCell{Color: "#9a9a9a", Title: fmt.Sprintf("%s (%d, %d)", tile.Theme, x, y)}
+			if biome := g.TileBiome(tile); biome != nil {
+				cell.Color = biome.Color
+			}
+			switch {
+			case x == g.CurrentX && y == g.CurrentY:
+				cell.Mark = "📍"
+			case tile.Item != nil:
+				cell.Mark = "🎁"
+				cell.Title += " - " + tile.Item.Name
+			}
+			row = append(row, cell)
+		}
+		grid = append(grid, row)
+	}
+	return grid
+}
+
+func journalMarkdown(w io.Writer, doc *journalDoc) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", doc.Title)
+	fmt.Fprintf(&b, "- World seed: `%d`\n- Days traveled: %d\n- Locations discovered: %d\n\n", doc.Seed, doc.Days, doc.Locations)
+
+	b.WriteString("## Map\n\n```\n")
+	b.WriteString(strings.Join(doc.Map, "\n"))
+	b.WriteString("\n```\n\n`@` you, `+` item found, `o` explored, `.` unexplored\n\n")
+
+	for _, day := range doc.Entries {
+		fmt.Fprintf(&b, "## Day %d: %s (%d, %d)\n\n", day.Day, day.Place, day.X, day.Y)
+		for _, entry := range day.Entries {
+			switch {
+			case entry.Kind == lib.EntryItem:
+				fmt.Fprintf(&b, "- 🎁 Found: **%s**\n\n", entry.Text)
+			case entry.Author == lib.AuthorPlayer:
+				if entry.Prompt != "" {
+					fmt.Fprintf(&b, "> *%s*\n>\n", entry.Prompt)
+				}
+				for _, line := range strings.Split(entry.Text, "\n") {
+					fmt.Fprintf(&b, "> %s\n", line)
+				}
+				b.WriteString("\n")
+			default:
+				fmt.Fprintf(&b, "%s\n\n", entry.Text)
+			}
+		}
+	}
+
+	if len(doc.Items) > 0 {
+		b.WriteString("## Items Found\n")
+		for _, group := range doc.Items {
+			fmt.Fprintf(&b, "\n### %s\n\n", group.Label)
+			for _, item := range group.Items {
+				fmt.Fprintf(&b, "- **%s**: %s *Found at %s on Day %d.*\n", item.Name, item.Description, item.FoundAt, item.FoundDay)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func journalText(w io.Writer, doc *journalDoc) error {
+	var b strings.Builder
+	rule := strings.Repeat("─", 60)
+
+	fmt.Fprintf(&b, "%s\n%s\n\n", doc.Title, rule)
+	fmt.Fprintf(&b, "World seed: %d\nDays traveled: %d\nLocations discovered: %d\n\n", doc.Seed, doc.Days, doc.Locations)
+	b.WriteString(strings.Join(doc.Map, "\n"))
+	b.WriteString("\n\n@ you  + item found  o explored  . unexplored\n\n")
+
+	for _, day := range doc.Entries {
+		fmt.Fprintf(&b, "%s\nDay %d: %s (%d, %d)\n%s\n\n", rule, day.Day, day.Place, day.X, day.Y, rule)
+		for _, entry := range day.Entries {
+			switch {
+			case entry.Kind == lib.EntryItem:
+				fmt.Fprintf(&b, "  → Found: %s\n\n", entry.Text)
+			case entry.Author == lib.AuthorPlayer:
+				if entry.Prompt != "" {
+					fmt.Fprintf(&b, "  “%s”\n", entry.Prompt)
+				}
+				for _, line := range strings.Split(entry.Text, "\n") {
+					fmt.Fprintf(&b, "  %s\n", line)
+				}
+				b.WriteString("\n")
+			default:
+				fmt.Fprintf(&b, "%s\n\n", entry.Text)
+			}
+		}
+	}
+
+	if len(doc.Items) > 0 {
+		fmt.Fprintf(&b, "%s\nItems Found\n%s\n", rule, rule)
+		for _, group := range doc.Items {
+			fmt.Fprintf(&b, "\n%s\n", group.Label)
+			for _, item := range group.Items {
+				fmt.Fprintf(&b, "  %s: %s Found at %s on Day %d.\n", item.Name, item.Description, item.FoundAt, item.FoundDay)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var journalHTML = template.Must(template.New("journal").Funcs(template.FuncMap{
+	"isItem":   func(e lib.JournalEntry) bool { return e.Kind == lib.EntryItem },
+	"isPlayer": func(e lib.JournalEntry) bool { return e.Author == lib.AuthorPlayer },
+	"lines":    func(s string) []string { return strings.Split(s, "\n") },
+	"css":      func(s string) template.CSS { return template.CSS(s) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 44em; margin: 2em auto; padding: 0 1em; color: #3b3228; background: #fbf7ef; line-height: 1.5; }
+h1, h2, h3 { font-weight: normal; }
+h2 { border-bottom: 1px solid #d8cdb8; padding-bottom: .2em; margin-top: 2em; }
+.stats { color: #7a6e5d; }
+table.map { border-collapse: collapse; margin: 1em 0; }
+table.map td { width: 1.6em; height: 1.6em; text-align: center; border: 1px solid #fbf7ef; font-size: .9em; }
+.item { color: #7a6e5d; }
+blockquote { margin: 1em 0; padding: .2em 1em; border-left: 3px solid #c9a66b; font-style: italic; }
+blockquote .prompt { color: #7a6e5d; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="stats">World seed {{.Seed}} &middot; {{.Days}} days &middot; {{.Locations}} locations</p>
+
+<h2>Map</h2>
+<table class="map">
+{{- range .Grid}}
+<tr>{{range .}}{{if .Color}}<td style="background: {{css .Color}}" title="{{.Title}}">{{.Mark}}</td>{{else}}<td></td>{{end}}{{end}}</tr>
+{{- end}}
+</table>
+{{range .Entries}}
+<h2>Day {{.Day}}: {{.Place}} <small>({{.X}}, {{.Y}})</small></h2>
+{{- range .Entries}}
+{{- if isItem .}}
+<p class="item">🎁 Found: <strong>{{.Text}}</strong></p>
+{{- else if isPlayer .}}
+<blockquote>{{if .Prompt}}<p class="prompt">{{.Prompt}}</p>{{end}}{{range lines .Text}}<p>{{.}}</p>{{end}}</blockquote>
+{{- else}}
+<p>{{.Text}}</p>
+{{- end}}
+{{- end}}
+{{end}}
+{{- if .Items}}
+<h2>Items Found</h2>
+{{- range .Items}}
+<h3>{{.Label}}</h3>
+<ul>
+{{- range .Items}}
+<li><strong>{{.Name}}</strong>: {{.Description}} <em>Found at {{.FoundAt}} on Day {{.FoundDay}}.</em></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
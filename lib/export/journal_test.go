@@ -0,0 +1,91 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// journey explores north then east from seed 42, writing on the way.
+func journey(t *testing.T, text string) *lib.Game {
+	t.Helper()
+	g := lib.NewGameWithSeed(42)
+	for i, dir := range []lib.Direction{lib.Directions[0], lib.Directions[2]} {
+		options := g.GenerateLocationOptions(g.CurrentX+dir.DX, g.CurrentY+dir.DY)
+		if _, err := g.Explore(dir, options[0]); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if err := g.Write(text, "What did you see?"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return g
+}
+
+func TestJournalGroupsByDay(t *testing.T) {
+	g := journey(t, "A quiet day.")
+	doc := newJournalDoc(g)
+
+	days := []int{}
+	for _, day := range doc.Entries {
+		days = append(days, day.Day)
+		if tile := g.GetTile(day.X, day.Y); day.Place != tile.Theme {
+			t.Errorf("day %d is headed %q, want %q", day.Day, day.Place, tile.Theme)
+		}
+		for _, entry := range day.Entries {
+			if entry.Day != day.Day {
+				t.Errorf("day %d holds %+v", day.Day, entry)
+			}
+		}
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(days, want) {
+		t.Fatalf("days %v, want %v", days, want)
+	}
+	if last := doc.Entries[1].Entries[len(doc.Entries[1].Entries)-1]; last.Author != lib.AuthorPlayer || last.Text != "A quiet day." {
+		t.Errorf("day 2 ends with %+v, want what was written", last)
+	}
+}
+
+func TestJournalHTMLEscapesPlayerText(t *testing.T) {
+	g := journey(t, "<script>alert(1)</script> & more")
+	var b bytes.Buffer
+	if err := Journal(&b, g, FormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "<script>") {
+		t.Error("player text was written out as HTML")
+	}
+	if !strings.Contains(b.String(), "&lt;script&gt;alert(1)&lt;/script&gt; &amp; more") {
+		t.Error("player text is missing from the journal")
+	}
+}
+
+func TestJournalUnknownFormat(t *testing.T) {
+	err := Journal(&bytes.Buffer{}, lib.NewGameWithSeed(42), "pdf")
+	if want := `unknown journal format "pdf" (choose from md, html, txt)`; err == nil || err.Error() != want {
+		t.Errorf("error is %v, want %s", err, want)
+	}
+}
+
+func TestTextMap(t *testing.T) {
+	g := lib.NewGameWithSeed(42)
+	options := g.GenerateLocationOptions(0, 1)
+	if _, err := g.Explore(lib.Directions[0], options[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	// The frontier rings the explored land, corners included
+	want := []string{
+		" . . .",
+		" . @ .",
+		" . o .",
+		" . . .",
+	}
+	if got := textMap(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("map is\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
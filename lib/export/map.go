@@ -0,0 +1,28 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Map export formats
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+// MapFormats lists every supported map export format.
+var MapFormats = []string{FormatPNG, FormatSVG}
+
+// Map draws the explored map in the given format.
+func Map(w io.Writer, g *lib.Game, format string) error {
+	switch format {
+	case FormatPNG:
+		return MapPNG(w, g)
+	case FormatSVG:
+		return MapSVG(w, g)
+	}
+	return fmt.Errorf("unknown map format %q (choose from %s)", format, strings.Join(MapFormats, ", "))
+}
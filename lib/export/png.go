@@ -0,0 +1,209 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Sizes of the rasterised map, in pixels
+const (
+	pngCell      = 48 // width and height of one tile
+	pngMargin    = 32 // room around the grid for coordinates
+	pngTitleArea = 56 // height of the title cartouche
+)
+
+var (
+	parchment  = color.RGBA{251, 247, 239, 255}
+	ink        = color.RGBA{59, 50, 40, 255}
+	faintInk   = color.RGBA{200, 189, 168, 255}
+	goldMarker = color.RGBA{230, 180, 40, 255}
+	youMarker  = color.RGBA{200, 50, 50, 255}
+)
+
+// MapPNG rasterises the explored map as a PNG: each tile in its biome colour
+// with the initials of its theme, a marker for items, the player's position,
+// grid coordinates and a title cartouche. It needs no window or display.
+func MapPNG(w io.Writer, g *lib.Game) error {
+	return png.Encode(w, MapImage(g))
+}
+
+// MapImage draws the explored map into an image.
+func MapImage(g *lib.Game) *image.RGBA {
+	minX, maxX, minY, maxY := g.Bounds()
+	// Leave a one-tile border so the unexplored edge shows
+	minX, maxX, minY, maxY = minX-1, maxX+1, minY-1, maxY+1
+
+	// The title must fit however narrow the map, whatever the seed
+	title := fmt.Sprintf("Gentle Wanderings - Day %d - Seed %d", g.TurnCount, g.Seed())
+
+	cols := maxX - minX + 1
+	rows := maxY - minY + 1
+	width := max(cols*pngCell, cartoucheWidth(title)) + 2*pngMargin
+	gridLeft := (width - cols*pngCell) / 2
+	gridTop := pngTitleArea + pngMargin
+	height := gridTop + rows*pngCell + pngMargin
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(parchment), image.Point{}, draw.Src)
+
+	drawCartouche(img, title)
+
+	// cellRect returns the pixel rectangle of tile (x, y); north is up
+	cellRect := func(x, y int) image.Rectangle {
+		left := gridLeft + (x-minX)*pngCell
+		top := gridTop + (maxY-y)*pngCell
+		return image.Rect(left, top, left+pngCell, top+pngCell)
+	}
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			r := cellRect(x, y)
+			tile := g.GetTile(x, y)
+			if tile == nil {
+				strokeRect(img, r, faintInk)
+				continue
+			}
+
+			fill := tileColor(g, tile)
+			draw.Draw(img, r.Inset(1), image.NewUniform(fill), image.Point{}, draw.Src)
+			strokeRect(img, r, darken(fill))
+			drawCentered(img, initials(tile.Theme), r.Min.X+pngCell/2, r.Min.Y+pngCell/2+4, contrastInk(fill))
+
+			if tile.Item != nil {
+				drawDiamond(img, r.Max.X-9, r.Min.Y+9, 5, goldMarker)
+			}
+			if x == g.CurrentX && y == g.CurrentY {
+				drawDisc(img, r.Min.X+pngCell/2, r.Max.Y-10, 6, color.White)
+				drawDisc(img, r.Min.X+pngCell/2, r.Max.Y-10, 4, youMarker)
+			}
+		}
+	}
+
+	// Grid coordinates along the bottom and left edges
+	for x := minX; x <= maxX; x++ {
+		r := cellRect(x, minY)
+		drawCentered(img, fmt.Sprint(x), r.Min.X+pngCell/2, r.Max.Y+16, ink)
+	}
+	for y := minY; y <= maxY; y++ {
+		r := cellRect(minX, y)
+		drawText(img, fmt.Sprintf("%3d", y), r.Min.X-26, r.Min.Y+pngCell/2+4, ink)
+	}
+
+	return img
+}
+
+// tileColor is the tile's biome colour, or one derived from its theme.
+func tileColor(g *lib.Game, tile *lib.Tile) color.RGBA {
+	if biome := g.TileBiome(tile); biome != nil {
+		r, gr, b := biome.RGB()
+		return color.RGBA{r, gr, b, 255}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(tile.Theme))
+	v := h.Sum32()
+	return color.RGBA{uint8(100 + v%100), uint8(120 + (v>>8)%100), uint8(90 + (v>>16)%100), 255}
+}
+
+func darken(c color.RGBA) color.RGBA {
+	return color.RGBA{c.R / 2, c.G / 2, c.B / 2, 255}
+}
+
+// contrastInk picks dark or light text to stay readable on c.
+func contrastInk(c color.RGBA) color.Color {
+	if int(c.R)*299+int(c.G)*587+int(c.B)*114 > 140000 {
+		return ink
+	}
+	return color.White
+}
+
+// initials shortens a theme to the first letter of each word, e.g. "MC".
+func initials(theme string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(theme) {
+		first, _ := utf8.DecodeRuneInString(word)
+		b.WriteRune(unicode.ToUpper(first))
+	}
+	return b.String()
+}
+
+// cartoucheWidth is the width of the cartouche framing title.
+func cartoucheWidth(title string) int {
+	return font.MeasureString(basicfont.Face7x13, title).Ceil() + 40
+}
+
+func drawCartouche(img *image.RGBA, title string) {
+	width := cartoucheWidth(title)
+	left := (img.Bounds().Dx() - width) / 2
+	r := image.Rect(left, 12, left+width, pngTitleArea-8)
+
+	draw.Draw(img, r, image.NewUniform(color.RGBA{239, 228, 204, 255}), image.Point{}, draw.Src)
+	strokeRect(img, r, ink)
+	strokeRect(img, r.Inset(3), ink)
+	drawCentered(img, title, left+width/2, r.Min.Y+r.Dy()/2+4, ink)
+}
+
+func strokeRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	for x := r.Min.X; x < r.Max.X; x++ {
+		img.Set(x, r.Min.Y, c)
+		img.Set(x, r.Max.Y-1, c)
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		img.Set(r.Min.X, y, c)
+		img.Set(r.Max.X-1, y, c)
+	}
+}
+
+func drawDisc(img *image.RGBA, cx, cy, radius int, c color.Color) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.Set(cx+x, cy+y, c)
+			}
+		}
+	}
+}
+
+func drawDiamond(img *image.RGBA, cx, cy, radius int, c color.Color) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if abs(x)+abs(y) <= radius {
+				img.Set(cx+x, cy+y, c)
+			}
+		}
+	}
+}
+
+func drawText(img *image.RGBA, text string, x, baseline int, c color.Color) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, baseline),
+	}
+	d.DrawString(text)
+}
+
+func drawCentered(img *image.RGBA, text string, cx, baseline int, c color.Color) {
+	width := font.MeasureString(basicfont.Face7x13, text).Ceil()
+	drawText(img, text, cx-width/2, baseline, c)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"math"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMapImageFitsTitle(t *testing.T) {
+	// A one-tile map with the longest seed there is
+	g := lib.NewGameWithSeed(math.MinInt64)
+	img := MapImage(g)
+
+	title := "Gentle Wanderings - Day 1 - Seed -9223372036854775808"
+	if width := img.Bounds().Dx(); width < cartoucheWidth(title) {
+		t.Errorf("image is %dpx wide, too narrow for a %dpx title", width, cartoucheWidth(title))
+	}
+}
+
+func TestInitials(t *testing.T) {
+	for theme, want := range map[string]string{
+		"Mushroom Circle": "MC",
+		"Éire":            "É",
+		"ørn  skog":       "ØS",
+	} {
+		got := initials(theme)
+		if got != want || !utf8.ValidString(got) {
+			t.Errorf("initials(%q) = %q, want %q", theme, got, want)
+		}
+	}
+}
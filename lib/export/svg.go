@@ -0,0 +1,190 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sizes of the SVG map, in user units
+const (
+	svgCell      = 64
+	svgMargin    = 40
+	svgTitleArea = 64
+	svgCompass   = 36 // radius of the compass rose
+	svgTitleEm   = 11 // a generous average glyph width in the 18 unit title
+)
+
+// visit is one step of the journey, in the order it was walked.
+type visit struct {
+	X, Y   int
+	Travel bool // reached by fast travel rather than a single step
+}
+
+// MapSVG writes the explored map as a standalone SVG. Each tile is a group
+// whose <title> shows its theme, coordinates, discovery and item when hovered,
+// and a path traces the tiles in the order they were visited.
+func MapSVG(w io.Writer, g *lib.Game) error {
+	minX, maxX, minY, maxY := g.Bounds()
+	minX, maxX, minY, maxY = minX-1, maxX+1, minY-1, maxY+1
+
+	cols := maxX - minX + 1
+	rows := maxY - minY + 1
+	title := fmt.Sprintf("Gentle Wanderings · Day %d · Seed %d", g.TurnCount, g.Seed())
+
+	gridLeft := svgMargin
+	gridTop := svgTitleArea + svgMargin
+	width := max(gridLeft+cols*svgCell+svgMargin+2*svgCompass+svgMargin, svgTitleWidth(title)+2*svgMargin)
+	height := gridTop + rows*svgCell + svgMargin
+	if least := gridTop + 2*svgCompass + svgMargin; height < least {
+		height = least
+	}
+
+	// center returns the middle of tile (x, y); north is up
+	center := func(x, y int) (int, int) {
+		return gridLeft + (x-minX)*svgCell + svgCell/2, gridTop + (maxY-y)*svgCell + svgCell/2
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Georgia, serif">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, "<title>Gentle Wanderings - Day %d - Seed %d</title>\n", g.TurnCount, g.Seed())
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#fbf7ef"/>`+"\n")
+
+	// Title cartouche
+	fmt.Fprintf(&b, `<g id="cartouche"><rect x="%d" y="12" width="%d" height="%d" fill="#efe4cc" stroke="#3b3228" stroke-width="2" rx="6"/>`, svgMargin, width-2*svgMargin, svgTitleArea-24)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="18" fill="#3b3228">%s</text></g>`+"\n", width/2, 12+(svgTitleArea-24)/2+6, esc(title))
+
+	// Tiles
+	b.WriteString(`<g id="tiles" font-size="11" text-anchor="middle">` + "\n")
+	for y := maxY; y >= minY; y-- {
+		for x := minX; x <= maxX; x++ {
+			cx, cy := center(x, y)
+			left, top := cx-svgCell/2, cy-svgCell/2
+			tile := g.GetTile(x, y)
+			if tile == nil {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="#e3d9c6"/>`+"\n", left, top, svgCell, svgCell)
+				continue
+			}
+
+			fill := "#9a9a9a"
+			if biome := g.TileBiome(tile); biome != nil {
+				fill = biome.Color
+			}
+			fmt.Fprintf(&b, `<g class="tile" data-x="%d" data-y="%d"><title>%s</title>`, x, y, esc(tileTooltip(g, tile)))
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="#fbf7ef" stroke-width="2"/>`, left, top, svgCell, svgCell, fill)
+			fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#fbf7ef">%s</text>`, cx, cy+4, esc(initials(tile.Theme)))
+			if tile.Item != nil {
+				fmt.Fprintf(&b, `<path d="M%d %d l6 6 l-6 6 l-6 -6 z" fill="#e6b428"/>`, left+svgCell-12, top+4)
+			}
+			b.WriteString("</g>\n")
+		}
+	}
+	b.WriteString("</g>\n")
+
+	// The route, in the order tiles were visited
+	visits := visitOrder(g)
+	b.WriteString(`<g id="route" fill="none" stroke="#3b3228" stroke-width="2" stroke-linecap="round" opacity="0.6">` + "\n")
+	for i := 1; i < len(visits); i++ {
+		x1, y1 := center(visits[i-1].X, visits[i-1].Y)
+		x2, y2 := center(visits[i].X, visits[i].Y)
+		dash := ""
+		if visits[i].Travel {
+			dash = ` stroke-dasharray="4 6"`
+		}
+		fmt.Fprintf(&b, `<path d="M%d %d L%d %d"%s><title>Step %d</title></path>`+"\n", x1, y1, x2, y2, dash, i)
+	}
+	b.WriteString("</g>\n")
+
+	// You are here
+	px, py := center(g.CurrentX, g.CurrentY)
+	fmt.Fprintf(&b, `<circle id="you" cx="%d" cy="%d" r="7" fill="#c83232" stroke="#ffffff" stroke-width="2"><title>You are here</title></circle>`+"\n", px, py+svgCell/4)
+
+	// Grid coordinates
+	b.WriteString(`<g id="coordinates" font-size="11" fill="#7a6e5d" text-anchor="middle">`)
+	for x := minX; x <= maxX; x++ {
+		cx, _ := center(x, minY)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%d</text>`, cx, gridTop+rows*svgCell+16, x)
+	}
+	for y := minY; y <= maxY; y++ {
+		_, cy := center(minX, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%d</text>`, gridLeft-16, cy+4, y)
+	}
+	b.WriteString("</g>\n")
+
+	compassRose(&b, width-svgMargin-svgCompass, gridTop+svgCompass)
+
+	b.WriteString("</svg>\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// svgTitleWidth is the width of the cartouche framing title. SVG text is
+// measured by the viewer, so this allows for wide fonts.
+func svgTitleWidth(title string) int {
+	return utf8.RuneCountInString(title)*svgTitleEm + 40
+}
+
+// tileTooltip is the hover text for a tile.
+func tileTooltip(g *lib.Game, tile *lib.Tile) string {
+	lines := []string{fmt.Sprintf("%s (%d, %d)", tile.Theme, tile.X, tile.Y)}
+	if biome := g.TileBiome(tile); biome != nil {
+		lines[0] += " - " + biome.Name
+	}
+	if tile.Discovery != "" {
+		lines = append(lines, tile.Discovery)
+	}
+	lines = append(lines, tile.Features...)
+	if tile.Item != nil {
+		lines = append(lines, fmt.Sprintf("Found: %s (Day %d)", tile.Item.Name, tile.Item.FoundDay))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// visitOrder reads the journal for every arrival, return and fast travel.
+// Fast travel passes each tile on its route; journals from before routes
+// were kept only say where it ended.
+func visitOrder(g *lib.Game) []visit {
+	visits := []visit{}
+	add := func(x, y int, travel bool) {
+		if n := len(visits); n > 0 && visits[n-1].X == x && visits[n-1].Y == y {
+			return
+		}
+		visits = append(visits, visit{X: x, Y: y, Travel: travel})
+	}
+	for _, entry := range g.JournalLog {
+		switch entry.Kind {
+		case lib.EntryArrival, lib.EntryReturn:
+			add(entry.X, entry.Y, false)
+		case lib.EntryTravel:
+			for _, at := range entry.Route {
+				add(at[0], at[1], true)
+			}
+			add(entry.X, entry.Y, true)
+		}
+	}
+	return visits
+}
+
+// compassRose draws a four-pointed compass centred on (cx, cy).
+func compassRose(b *bytes.Buffer, cx, cy int) {
+	r, k := svgCompass-10, 6
+	fmt.Fprintf(b, `<g id="compass" font-size="12" fill="#3b3228" text-anchor="middle">`)
+	fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="#c9a66b"/>`, cx, cy, svgCompass-8)
+	fmt.Fprintf(b, `<path d="M%d %d L%d %d L%d %d L%d %d Z" fill="#3b3228"/>`, cx, cy-r, cx+k, cy, cx, cy+r, cx-k, cy)
+	fmt.Fprintf(b, `<path d="M%d %d L%d %d L%d %d L%d %d Z" fill="#c9a66b"/>`, cx-r, cy, cx, cy-k, cx+r, cy, cx, cy+k)
+	fmt.Fprintf(b, `<path d="M%d %d L%d %d L%d %d Z" fill="#c83232"/>`, cx, cy-r, cx+k, cy, cx-k, cy)
+	fmt.Fprintf(b, `<text x="%d" y="%d">N</text><text x="%d" y="%d">S</text>`, cx, cy-svgCompass-2, cx, cy+svgCompass+12)
+	fmt.Fprintf(b, `<text x="%d" y="%d">E</text><text x="%d" y="%d">W</text>`, cx+svgCompass+8, cy+4, cx-svgCompass-8, cy+4)
+	b.WriteString("</g>\n")
+}
+
+// esc escapes text for use in SVG content.
+func esc(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
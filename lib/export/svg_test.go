@@ -0,0 +1,70 @@
+package export
+
+import (
+	"GentleWanderings/lib"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMapSVGRouteFollowsTravel(t *testing.T) {
+	// A horseshoe: north, east twice, then south, so fast travel home has to
+	// go back round rather than across the unexplored gap
+	g := lib.NewGameWithSeed(3)
+	for _, dir := range []lib.Direction{lib.Directions[0], lib.Directions[2], lib.Directions[2], lib.Directions[1]} {
+		options := g.GenerateLocationOptions(g.CurrentX+dir.DX, g.CurrentY+dir.DY)
+		if _, err := g.Explore(dir, options[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Travel(g.FindPath(0, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := MapSVG(&b, g); err != nil {
+		t.Fatal(err)
+	}
+	steps := regexp.MustCompile(`<path d="M(-?\d+) (-?\d+) L(-?\d+) (-?\d+)"`).FindAllStringSubmatch(b.String(), -1)
+	if len(steps) != 8 {
+		t.Errorf("route has %d steps, want 4 explored and 4 travelled", len(steps))
+	}
+	for _, step := range steps {
+		var x1, y1, x2, y2 int
+		fmt.Sscan(step[1]+" "+step[2]+" "+step[3]+" "+step[4], &x1, &y1, &x2, &y2)
+		if d := abs(x2-x1) + abs(y2-y1); d != svgCell {
+			t.Errorf("route step %s crosses %d units, want one tile of %d", step[0], d, svgCell)
+		}
+	}
+}
+
+func TestMapSVGFitsTitle(t *testing.T) {
+	// A one-tile map with a seed the size time.Now().UnixNano() gives
+	g := lib.NewGameWithSeed(1760000000123456789)
+	var b bytes.Buffer
+	if err := MapSVG(&b, g); err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+
+	canvas := regexp.MustCompile(`<svg [^>]*width="(\d+)"`).FindStringSubmatch(svg)
+	box := regexp.MustCompile(`<g id="cartouche"><rect x="(\d+)" y="\d+" width="(\d+)"`).FindStringSubmatch(svg)
+	if canvas == nil || box == nil {
+		t.Fatal("no canvas size or cartouche")
+	}
+	width, _ := strconv.Atoi(canvas[1])
+	left, _ := strconv.Atoi(box[1])
+	inner, _ := strconv.Atoi(box[2])
+
+	// Georgia averages about half an em, so at 18 units a title needs 9 a character
+	title := "Gentle Wanderings · Day 1 · Seed 1760000000123456789"
+	if need := utf8.RuneCountInString(title) * 9; inner < need {
+		t.Errorf("cartouche is %d wide, too narrow for a title of about %d", inner, need)
+	}
+	if left+inner > width {
+		t.Errorf("cartouche reaches %d on a %d wide canvas", left+inner, width)
+	}
+}
@@ -1,8 +1,7 @@
 package lib
 
 import (
-	"GentleWanderings/lib/printer"
-	"bufio"
+	"GentleWanderings/lib/content"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -15,22 +14,44 @@ type Game struct {
 	CurrentX   int
 	CurrentY   int
 	TurnCount  int
-	JournalLog []string
+	JournalLog []JournalEntry
 	Inventory  []*Item
 	rand       *rand.Rand
+	source     *countingSource
+	content    *content.Pack
+	view       mapView    // where the map is scrolled to; not saved
+	log        *ActionLog // every action taken, or nil if the journey began before logging
+	prompt     *Prompt    // the card drawn and not yet answered
+	history    history    // what Undo and Redo go back and forth between; not saved
 }
 
-// NewGame initializes a new game
+// NewGame initializes a new game with a random seed
 func NewGame() *Game {
-	source := rand.NewSource(time.Now().UnixNano())
+	return NewGameWithSeed(time.Now().UnixNano())
+}
+
+// NewGameWithSeed initializes a new game whose world is generated from seed,
+// so two players with the same seed wander the same world
+func NewGameWithSeed(seed int64) *Game {
+	return NewGameWithContent(seed, content.Default())
+}
+
+// NewGameWithContent initializes a new game generated from seed and told
+// with the flavour text in pack, starting tile included
+func NewGameWithContent(seed int64, pack *content.Pack) *Game {
+	source := newCountingSource(seed)
 	g := &Game{
 		Map:        make(map[string]*Tile),
 		CurrentX:   0,
 		CurrentY:   0,
 		TurnCount:  1,
-		JournalLog: []string{},
+		JournalLog: []JournalEntry{},
 		Inventory:  []*Item{},
 		rand:       rand.New(source),
+		source:     source,
+		content:    pack,
+		log:        &ActionLog{Seed: seed, Actions: []Action{}},
+		history:    history{depth: DefaultHistory},
 	}
 
 	// Create starting tile
@@ -42,96 +63,91 @@ func NewGame() *Game {
 		Discovery:   "You begin your journey here, where the world feels safe and full of possibility.",
 		Visited:     true,
 	}
+	if biome := g.BiomeAt(0, 0); biome != nil {
+		startTile.Biome = biome.ID
+	}
 	g.Map[g.tileKey(0, 0)] = startTile
-	g.JournalLog = append(g.JournalLog, "Day 1: "+startTile.Discovery)
+	g.logEntry(EntryArrival, startTile.Discovery)
 
 	return g
 }
 
-// GenerateDiscovery creates a discovery event for the new location
-func (g *Game) GenerateDiscovery(theme string) string {
-	discoveries := []string{
-		"You discover %s and feel a deep connection to this place.",
-		"As you arrive at %s, you notice details you hadn't expected.",
-		"The %s reveals itself slowly, inviting you to linger.",
-		"%s feels like it has been waiting for you.",
-		"You find yourself drawn deeper into %s.",
-	}
+// Seed returns the seed the world is generated from
+func (g *Game) Seed() int64 {
+	return g.source.seed
+}
+
+// GenerateDiscovery creates a discovery event for the new location at (x, y)
+func (g *Game) GenerateDiscovery(x, y int, theme string) string {
+	r := g.tileRand(x, y, "discovery:"+theme)
+	discoveries := g.content.Discoveries
 
-	template := discoveries[g.rand.Intn(len(discoveries))]
+	template := discoveries[r.Intn(len(discoveries))]
 	return fmt.Sprintf(template, strings.ToLower(theme))
 }
 
-// GenerateItem creates a random item (or returns nil if no item)
-func (g *Game) GenerateItem(theme string, turnCount int) *Item {
-	// 60% chance to find an item
-	if g.rand.Float32() > 0.6 {
-		return nil
-	}
+// GenerateItem creates a random item for the location at (x, y) (or returns nil if no item)
+func (g *Game) GenerateItem(x, y int, theme string, turnCount int) *Item {
+	r := g.tileRand(x, y, "item:"+theme)
 
-	itemsByCategory := map[string][]string{
-		"keepsake": {
-			"Smooth River Stone", "Pressed Flower", "Acorn Cap", "Bird Feather",
-			"Seashell Fragment", "Dried Leaf", "Pinecone", "Lucky Pebble",
-			"Glass Bead", "Carved Twig", "Moss Sample", "Butterfly Wing",
-		},
-		"treasure": {
-			"Ancient Coin", "Crystal Shard", "Silver Locket", "Brass Key",
-			"Jade Figurine", "Pearl", "Golden Ring", "Copper Medallion",
-			"Gemstone", "Amber", "Moonstone", "Opal",
-		},
-		"curiosity": {
-			"Strange Map Fragment", "Mysterious Note", "Odd Compass", "Faded Photograph",
-			"Old Journal Page", "Weathered Letter", "Riddle Scroll", "Poetry Fragment",
-			"Sheet Music", "Recipe Card", "Star Chart", "Encrypted Message",
-		},
-	}
-
-	categories := []string{"keepsake", "treasure", "curiosity"}
-	category := categories[g.rand.Intn(len(categories))]
-	items := itemsByCategory[category]
-	itemName := items[g.rand.Intn(len(items))]
-
-	descriptions := map[string][]string{
-		"keepsake": {
-			"A simple treasure that reminds you of this moment.",
-			"Something small but meaningful.",
-			"A gentle reminder of your journey.",
-			"It feels right to carry this with you.",
-		},
-		"treasure": {
-			"It glimmers softly in your hand, valuable yet mysterious.",
-			"Worth keeping safe - who knows its story?",
-			"A prize from your wanderings.",
-			"Something precious, left behind long ago.",
-		},
-		"curiosity": {
-			"This raises more questions than it answers.",
-			"You sense there's a story here, waiting to unfold.",
-			"Strange and intriguing - you must learn more.",
-			"A puzzle piece from someone else's tale.",
-		},
+	if r.Float64() >= g.content.Chance() {
+		return nil
 	}
 
-	descList := descriptions[category]
-	desc := descList[g.rand.Intn(len(descList))]
+	category := g.pickCategory(r, x, y)
+	itemName := category.Names[r.Intn(len(category.Names))]
+	desc := category.Descriptions[r.Intn(len(category.Descriptions))]
 
 	return &Item{
 		Name:        itemName,
 		Description: desc,
-		Category:    category,
+		Category:    category.ID,
 		FoundAt:     theme,
 		FoundDay:    turnCount,
 	}
 }
 
-// Explore creates a new tile in the given direction
-func (g *Game) Explore(dir Direction, option LocationOption) *Item {
+// pickCategory chooses an item category, weighted by the biome at (x, y)
+func (g *Game) pickCategory(r *rand.Rand, x, y int) content.Category {
+	categories := g.content.Categories
+	biome := g.BiomeAt(x, y)
+	if biome == nil {
+		return categories[r.Intn(len(categories))]
+	}
+
+	total := 0
+	for _, cat := range categories {
+		total += biome.Weight(cat.ID)
+	}
+	roll := r.Intn(total)
+	for _, cat := range categories {
+		roll -= biome.Weight(cat.ID)
+		if roll < 0 {
+			return cat
+		}
+	}
+	return categories[len(categories)-1]
+}
+
+// Explore creates a new tile in the given direction and moves onto it
+func (g *Game) Explore(dir Direction, option LocationOption) (*Item, error) {
+	out, err := g.Apply(Action{Kind: ActionExplore, Direction: dir.Name, Theme: option.Theme, Description: option.Description})
+	if err != nil {
+		return nil, err
+	}
+	return out.Item, nil
+}
+
+func (g *Game) explore(dir Direction, option LocationOption) (*Item, error) {
 	newX := g.CurrentX + dir.DX
 	newY := g.CurrentY + dir.DY
 
-	discovery := g.GenerateDiscovery(option.Theme)
-	item := g.GenerateItem(option.Theme, g.TurnCount)
+	if existing := g.GetTile(newX, newY); existing != nil {
+		return nil, fmt.Errorf("%s has already been explored", existing.Theme)
+	}
+
+	discovery := g.GenerateDiscovery(newX, newY, option.Theme)
+	item := g.GenerateItem(newX, newY, option.Theme, g.TurnCount+1)
 
 	newTile := &Tile{
 		X:           newX,
@@ -142,197 +158,108 @@ func (g *Game) Explore(dir Direction, option LocationOption) *Item {
 		Visited:     true,
 		Item:        item,
 	}
+	if biome := g.BiomeAt(newX, newY); biome != nil {
+		newTile.Biome = biome.ID
+	}
 
 	g.Map[g.tileKey(newX, newY)] = newTile
 	g.CurrentX = newX
 	g.CurrentY = newY
 	g.TurnCount++
 
-	logEntry := fmt.Sprintf("Day %d: %s", g.TurnCount, discovery)
-	g.JournalLog = append(g.JournalLog, logEntry)
+	g.logEntry(EntryArrival, discovery)
 
 	if item != nil {
 		g.Inventory = append(g.Inventory, item)
-		logEntry := fmt.Sprintf("  → Found: %s", item.Name)
-		g.JournalLog = append(g.JournalLog, logEntry)
+		g.logEntry(EntryItem, item.Name)
 	}
 
-	return item
+	return item, nil
 }
 
-// ShowInventory displays the player's collected items
-func (g *Game) ShowInventory() {
-	printer.ShowInventory()
-
-	if len(g.Inventory) == 0 {
-		fmt.Println("\nYour pack is empty. Perhaps you'll find something as you wander...")
-		fmt.Println()
-		return
-	}
-
-	// Group by category
-	categories := map[string][]*Item{
-		"keepsake":  {},
-		"treasure":  {},
-		"curiosity": {},
-	}
+// Move walks onto an already explored tile in the given direction. It costs
+// a day but generates nothing new.
+func (g *Game) Move(dir Direction) error {
+	_, err := g.Apply(Action{Kind: ActionMove, Direction: dir.Name})
+	return err
+}
 
-	for _, item := range g.Inventory {
-		categories[item.Category] = append(categories[item.Category], item)
-	}
+func (g *Game) move(dir Direction) error {
+	newX := g.CurrentX + dir.DX
+	newY := g.CurrentY + dir.DY
 
-	categoryNames := map[string]string{
-		"keepsake":  "🍃 Keepsakes",
-		"treasure":  "💎 Treasures",
-		"curiosity": "❓ Curiosities",
+	tile := g.GetTile(newX, newY)
+	if tile == nil {
+		return fmt.Errorf("nothing has been explored to the %s yet", strings.ToLower(dir.Name))
 	}
 
-	categoryOrder := []string{"keepsake", "treasure", "curiosity"}
-
-	for _, cat := range categoryOrder {
-		items := categories[cat]
-		if len(items) == 0 {
-			continue
-		}
+	g.CurrentX = newX
+	g.CurrentY = newY
+	g.TurnCount++
 
-		fmt.Printf("\n%s (%d)\n", categoryNames[cat], len(items))
-		fmt.Println(strings.Repeat("─", 60))
+	template := g.content.Returns[g.rand.Intn(len(g.content.Returns))]
+	text := fmt.Sprintf(template, strings.ToLower(tile.Theme))
+	g.logEntry(EntryReturn, strings.ToUpper(text[:1])+text[1:])
 
-		for i, item := range items {
-			fmt.Printf("%d. %s\n", i+1, item.Name)
-			fmt.Printf("   %s\n", item.Description)
-			fmt.Printf("   Found at %s on Day %d\n", item.FoundAt, item.FoundDay)
-			if i < len(items)-1 {
-				fmt.Println()
-			}
-		}
-	}
-
-	fmt.Printf("\n%s Total items collected: %d\n", strings.Repeat("─", 60), len(g.Inventory))
-	fmt.Println()
+	return nil
 }
 
-// ShowMenu displays the main game menu
-func (g *Game) ShowMenu(scanner *bufio.Scanner) {
-	for {
-		printer.ShowMenu() // prints out the menu in the lib printer
-		if !scanner.Scan() {
-			return
-		}
-
-		choice := strings.TrimSpace(scanner.Text())
-
-		switch choice {
-		case "1":
-			g.ShowMap()
-		case "2":
-			g.ShowDetailedMap()
-		case "3":
-			g.ShowInventory()
-		case "4":
-			g.ShowJournal()
-		case "5":
-			g.ShowCurrentLocation()
-		case "6":
-			g.ShowStatistics()
-		case "7":
-			fmt.Println("\nReturning to your journey...")
-			return
-		default:
-			fmt.Println("\nInvalid choice. Please try again.")
-		}
-
-		fmt.Print("\nPress Enter to continue...")
-		scanner.Scan()
-	}
+// ItemGroup is the items of one category in the inventory
+type ItemGroup struct {
+	Category string
+	Label    string
+	Items    []*Item
 }
 
-// ShowJournal displays the journey log
-func (g *Game) ShowJournal() {
-	printer.ShowJournal()
-
-	for _, entry := range g.JournalLog {
-		if strings.HasPrefix(entry, "  →") {
-			fmt.Println(entry) // Item entries
-		} else {
-			fmt.Println(entry) // Day entries
+// InventoryGroups returns the inventory grouped by category, leaving out
+// categories with nothing in them
+func (g *Game) InventoryGroups() []ItemGroup {
+	groups := []ItemGroup{}
+	for _, cat := range g.CategoryOrder() {
+		group := ItemGroup{Category: cat, Label: g.CategoryLabel(cat)}
+		for _, item := range g.Inventory {
+			if item.Category == cat {
+				group.Items = append(group.Items, item)
+			}
+		}
+		if len(group.Items) > 0 {
+			groups = append(groups, group)
 		}
 	}
-	fmt.Println()
+	return groups
 }
 
-// ShowCurrentLocation displays detailed info about current location
-func (g *Game) ShowCurrentLocation() {
-	tile := g.GetTile(g.CurrentX, g.CurrentY)
-	if tile == nil {
-		return
-	}
-
-	printer.ShowCurrentLocation()
-
-	// TODO: Move printing function to the printer
-	fmt.Printf("🌿 %s\n", tile.Theme)
-	fmt.Printf("📍 Position: (%d, %d)\n\n", tile.X, tile.Y)
-	fmt.Printf("%s\n\n", tile.Description)
-
-	if tile.Item != nil {
-		fmt.Printf("🎁 You found: %s\n", tile.Item.Name)
-		fmt.Printf("   %s\n", tile.Item.Description)
-	} else {
-		fmt.Println("This location holds no items, just peaceful presence.")
+// CategoryOrder lists item categories in content pack order, followed by any
+// categories in the inventory that the current content no longer defines
+func (g *Game) CategoryOrder() []string {
+	order := []string{}
+	known := map[string]bool{}
+	for _, cat := range g.content.Categories {
+		order = append(order, cat.ID)
+		known[cat.ID] = true
 	}
-	fmt.Println()
-}
-
-// ShowStatistics displays game statistics
-func (g *Game) ShowStatistics() {
-	printer.ShowStatistics()
-
-	fmt.Printf("🗓️  Days Traveled: %d\n", g.TurnCount)
-	fmt.Printf("🗺️  Locations Discovered: %d\n", len(g.Map))
-	fmt.Printf("🎒 Items Collected: %d\n", len(g.Inventory))
-
-	// Count by category
-	categories := map[string]int{}
 	for _, item := range g.Inventory {
-		categories[item.Category]++
-	}
-
-	if len(g.Inventory) > 0 {
-		fmt.Println("\nCollection breakdown:")
-		if categories["keepsake"] > 0 {
-			fmt.Printf("  🍃 Keepsakes: %d\n", categories["keepsake"])
-		}
-		if categories["treasure"] > 0 {
-			fmt.Printf("  💎 Treasures: %d\n", categories["treasure"])
-		}
-		if categories["curiosity"] > 0 {
-			fmt.Printf("  ❓ Curiosities: %d\n", categories["curiosity"])
+		if !known[item.Category] {
+			order = append(order, item.Category)
+			known[item.Category] = true
 		}
 	}
+	return order
+}
 
-	// Calculate exploration extent
-	minX, maxX := 0, 0
-	minY, maxY := 0, 0
-	for _, tile := range g.Map {
-		if tile.X < minX {
-			minX = tile.X
-		}
-		if tile.X > maxX {
-			maxX = tile.X
-		}
-		if tile.Y < minY {
-			minY = tile.Y
-		}
-		if tile.Y > maxY {
-			maxY = tile.Y
-		}
+// CategoryLabel returns the display label for an item category
+func (g *Game) CategoryLabel(id string) string {
+	if cat := g.content.Category(id); cat != nil {
+		return cat.Label
 	}
+	return id
+}
 
-	width := maxX - minX + 1
-	height := maxY - minY + 1
-
-	fmt.Printf("\n🧭 Map Dimensions: %d × %d\n", width, height)
-	fmt.Printf("📏 Furthest North: %d, South: %d, East: %d, West: %d\n", maxY, minY, maxX, minX)
-	fmt.Println()
+// Replace swaps in a journey loaded from a save, keeping the content packs
+// the current journey uses and how far back it can undo
+func (g *Game) Replace(loaded *Game) {
+	pack, depth := g.content, g.history.depth
+	*g = *loaded
+	g.content = pack
+	g.SetHistory(depth)
 }
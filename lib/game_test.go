@@ -0,0 +1,71 @@
+package lib
+
+import (
+	"GentleWanderings/lib/content"
+	"reflect"
+	"testing"
+)
+
+func TestStartTileUsesContent(t *testing.T) {
+	// With no biomes in the pack the start tile lies in none
+	pack := *content.Default()
+	pack.Biomes = nil
+
+	if g := NewGameWithSeed(7); g.CurrentTile().Biome == "" {
+		t.Fatal("the default start tile has no biome")
+	}
+	if g := NewGameWithContent(7, &pack); g.CurrentTile().Biome != "" {
+		t.Errorf("start tile lies in %q with a pack that has no biomes", g.CurrentTile().Biome)
+	}
+	replayed, err := Replay(&ActionLog{Seed: 7}, &pack, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if biome := replayed.CurrentTile().Biome; biome != "" {
+		t.Errorf("replayed start tile lies in %q with a pack that has no biomes", biome)
+	}
+}
+
+func TestTileIndependentOfRoute(t *testing.T) {
+	found := false
+	for seed := int64(1); seed <= 50; seed++ {
+		// Reach (1, 1) north then east, and east then north
+		var tiles []*Tile
+		var options [][]LocationOption
+		for _, route := range [][]string{{"North", "East"}, {"East", "North"}} {
+			g := NewGameWithSeed(seed)
+			wander(t, g, route[0])
+			options = append(options, g.GenerateLocationOptions(1, 1))
+			wander(t, g, route[1])
+			tiles = append(tiles, g.GetTile(1, 1))
+		}
+
+		a, b := tiles[0], tiles[1]
+		if !reflect.DeepEqual(options[0], options[1]) {
+			t.Errorf("seed %d: paths offered differ:\n%v\n%v", seed, options[0], options[1])
+		}
+		if a.Discovery != b.Discovery {
+			t.Errorf("seed %d: discoveries differ: %q and %q", seed, a.Discovery, b.Discovery)
+		}
+		if !reflect.DeepEqual(a.Item, b.Item) {
+			t.Errorf("seed %d: items differ: %v and %v", seed, a.Item, b.Item)
+		}
+		found = found || a.Item != nil
+	}
+	if !found {
+		t.Error("no seed found an item to compare")
+	}
+}
+
+func TestDirectionNamed(t *testing.T) {
+	for name, want := range map[string]string{"north": "North", "WEST": "West", "e": "East", "S": "South"} {
+		if dir, err := DirectionNamed(name); err != nil || dir.Name != want {
+			t.Errorf("DirectionNamed(%q) = %v, %v, want %s", name, dir.Name, err, want)
+		}
+	}
+	for _, name := range []string{"", "up", "no"} {
+		if _, err := DirectionNamed(name); err == nil {
+			t.Errorf("DirectionNamed(%q) found a direction", name)
+		}
+	}
+}
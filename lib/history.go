@@ -0,0 +1,157 @@
+package lib
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// DefaultHistory is how many explorations Undo can take back unless
+// SetHistory says otherwise
+const DefaultHistory = 10
+
+// snapshot is a journey as it stood just before the player explored
+// somewhere new, with the actions taken from then until the next snapshot.
+type snapshot struct {
+	tiles      []Tile
+	currentX   int
+	currentY   int
+	turnCount  int
+	journalLog []JournalEntry
+	inventory  []*Item
+	draws      uint64 // values drawn from the generator
+	logLen     int    // actions in the action log
+	actions    []Action
+}
+
+// history holds the snapshots Undo goes back to and the actions Redo plays
+// again.
+type history struct {
+	depth int
+	undo  []*snapshot
+	redo  [][]Action // the actions each undo took back, the latest last
+}
+
+// SetHistory sets how many explorations Undo can take back; 0 turns undo off.
+func (g *Game) SetHistory(depth int) {
+	g.history.depth = max(depth, 0)
+	if over := len(g.history.undo) - g.history.depth; over > 0 {
+		g.history.undo = g.history.undo[over:]
+	}
+}
+
+// CanUndo reports whether there is an exploration to undo.
+func (g *Game) CanUndo() bool {
+	return len(g.history.undo) > 0
+}
+
+// CanRedo reports whether there is an undo to take back.
+func (g *Game) CanRedo() bool {
+	return len(g.history.redo) > 0
+}
+
+// Undo goes back to just before the last exploration: the tile it found,
+// the journal entries and items since, and the generator all return to how
+// they were. Anything done after it is undone too.
+func (g *Game) Undo() error {
+	if !g.CanUndo() {
+		return errors.New("there is nothing to undo")
+	}
+	h := &g.history
+	s := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, s.actions)
+	g.restore(s)
+	return nil
+}
+
+// Redo takes back the last Undo, playing the same steps again.
+func (g *Game) Redo() error {
+	if !g.CanRedo() {
+		return errors.New("there is nothing to redo")
+	}
+	h := &g.history
+	actions := h.redo[len(h.redo)-1]
+	rest := h.redo[:len(h.redo)-1]
+
+	// Should a step fail, everything goes back to how it was
+	before, undo, redo := g.capture(), h.undo, h.redo
+	for _, a := range actions {
+		if _, err := g.Apply(a); err != nil {
+			g.restore(before)
+			h.undo, h.redo = undo, redo
+			return err
+		}
+	}
+	// Applying the actions again forgets what else could be redone
+	h.redo = rest
+	return nil
+}
+
+// remember records the action just applied: a snapshot of the journey
+// before it is taken first if it explores, and anything new the player
+// does means there is nothing left to redo.
+func (g *Game) remember(a Action, before *snapshot) {
+	h := &g.history
+	h.redo = nil
+	if before != nil {
+		h.undo = append(h.undo, before)
+		if len(h.undo) > h.depth {
+			h.undo = h.undo[1:]
+		}
+	}
+	if len(h.undo) > 0 {
+		last := h.undo[len(h.undo)-1]
+		last.actions = append(last.actions, a)
+	}
+}
+
+// snapshot copies the journey as it stands, or returns nil if undo is off.
+func (g *Game) snapshot() *snapshot {
+	if g.history.depth == 0 {
+		return nil
+	}
+	return g.capture()
+}
+
+// capture copies the journey as it stands.
+func (g *Game) capture() *snapshot {
+	s := &snapshot{
+		currentX:   g.CurrentX,
+		currentY:   g.CurrentY,
+		turnCount:  g.TurnCount,
+		journalLog: append([]JournalEntry{}, g.JournalLog...),
+		inventory:  append([]*Item{}, g.Inventory...),
+		draws:      g.source.draws,
+	}
+	// Prompt cards add features to tiles, so each tile is copied
+	for _, tile := range g.Map {
+		t := *tile
+		t.Features = append([]string(nil), tile.Features...)
+		s.tiles = append(s.tiles, t)
+	}
+	if g.log != nil {
+		s.logLen = len(g.log.Actions)
+	}
+	return s
+}
+
+// restore puts the journey back as it was when s was taken.
+func (g *Game) restore(s *snapshot) {
+	g.Map = make(map[string]*Tile, len(s.tiles))
+	for _, tile := range s.tiles {
+		t := tile
+		g.Map[g.tileKey(t.X, t.Y)] = &t
+	}
+	g.CurrentX, g.CurrentY = s.currentX, s.currentY
+	g.TurnCount = s.turnCount
+	g.JournalLog = s.journalLog
+	g.Inventory = s.inventory
+	g.prompt = nil
+
+	g.source = newCountingSource(g.source.seed)
+	g.source.skip(s.draws)
+	g.rand = rand.New(g.source)
+	if g.log != nil {
+		g.log.Actions = g.log.Actions[:s.logLen]
+	}
+}
@@ -0,0 +1,133 @@
+package lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// exploreFinding wanders north from seed until arriving somewhere turns up
+// an item.
+func exploreFinding(t *testing.T) (g *Game, before *Game) {
+	t.Helper()
+	for seed := int64(1); seed < 500; seed++ {
+		g := NewGameWithSeed(seed)
+		wander(t, g, "North")
+		before := roundTrip(t, g)
+		wander(t, g, "East")
+		if g.CurrentTile().Item != nil {
+			return g, before
+		}
+	}
+	t.Fatal("nothing was ever found")
+	return nil, nil
+}
+
+func TestUndoRestoresJourney(t *testing.T) {
+	g, before := exploreFinding(t)
+	if err := g.Write("Something glints.", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if g.GetTile(1, 1) != nil {
+		t.Error("the tile explored is still there")
+	}
+	sameJourney(t, g, before)
+
+	// The generator is back where it was, so what comes next is the same
+	for i := 0; i < 5; i++ {
+		if got, want := g.rand.Int63(), before.rand.Int63(); got != want {
+			t.Fatalf("draw %d after undoing is %d, want %d", i+1, got, want)
+		}
+	}
+}
+
+func TestRedoPlaysAgain(t *testing.T) {
+	g, _ := exploreFinding(t)
+	if err := g.Write("Something glints.", ""); err != nil {
+		t.Fatal(err)
+	}
+	after := roundTrip(t, g)
+
+	if err := g.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	sameJourney(t, g, after)
+	if g.CanRedo() {
+		t.Error("there is still something to redo")
+	}
+}
+
+func TestRedoFailingLeavesUndoneJourney(t *testing.T) {
+	g := NewGameWithSeed(5)
+	wander(t, g, "North", "East")
+	if err := g.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	undone := roundTrip(t, g)
+
+	// A step that can't be taken again stops the redo
+	h := &g.history
+	last := len(h.redo) - 1
+	h.redo[last] = append(h.redo[last], Action{Kind: ActionMove, Direction: "East"})
+	if err := g.Redo(); err == nil {
+		t.Fatal("redid a move onto unexplored land")
+	}
+	sameJourney(t, g, undone)
+	if !g.CanRedo() || len(h.redo[len(h.redo)-1]) != 2 {
+		t.Errorf("redo stack is %v, want it kept", h.redo)
+	}
+	if len(h.undo) != 1 {
+		t.Errorf("%d explorations to undo, want 1", len(h.undo))
+	}
+}
+
+func TestHistoryDepth(t *testing.T) {
+	g := NewGameWithSeed(5)
+	g.SetHistory(2)
+	wander(t, g, "North", "North", "North", "North")
+
+	for i := 0; i < 2; i++ {
+		if err := g.Undo(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.CanUndo() {
+		t.Error("undid more than the history holds")
+	}
+	if g.CurrentY != 2 || len(g.Map) != 3 {
+		t.Errorf("back at (%d, %d) with %d tiles, want (0, 2) with 3", g.CurrentX, g.CurrentY, len(g.Map))
+	}
+
+	g.SetHistory(0)
+	wander(t, g, "East")
+	if g.CanUndo() {
+		t.Error("undo is off but there is something to undo")
+	}
+}
+
+func TestNewActionClearsRedo(t *testing.T) {
+	g := NewGameWithSeed(5)
+	wander(t, g, "North")
+	if err := g.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if !g.CanRedo() {
+		t.Fatal("nothing to redo after undoing")
+	}
+	wander(t, g, "East")
+	if g.CanRedo() {
+		t.Error("exploring somewhere else left the old way to redo")
+	}
+	if err := g.Redo(); err == nil {
+		t.Error("redid after exploring somewhere else")
+	}
+	if want := []Action{{Kind: ActionExplore, Direction: "East", Theme: g.CurrentTile().Theme, Description: g.CurrentTile().Description}}; !reflect.DeepEqual(g.Log().Actions, want) {
+		t.Errorf("action log is %v, want %v", g.Log().Actions, want)
+	}
+}
@@ -2,9 +2,9 @@ package lib
 
 // Item represents a collectible object
 type Item struct {
-	Name        string
-	Description string
-	Category    string // keepsake, treasure, curiosity
-	FoundAt     string
-	FoundDay    int
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"` // keepsake, treasure, curiosity
+	FoundAt     string `json:"found_at"`
+	FoundDay    int    `json:"found_day"`
 }
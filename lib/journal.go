@@ -0,0 +1,110 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Journal entry authors
+const (
+	AuthorEngine = "engine"
+	AuthorPlayer = "player"
+)
+
+// Journal entry kinds
+const (
+	EntryArrival = "arrival" // reaching a newly explored tile
+	EntryReturn  = "return"  // walking back onto an explored tile
+	EntryTravel  = "travel"  // fast travel across the map
+	EntryItem    = "item"    // picking up an item
+	EntryOracle  = "oracle"  // the outcome of a prompt card
+	EntryNote    = "note"    // something the player wrote
+)
+
+// JournalEntry is one record in the journey log, tied to the day and tile it
+// was written on.
+type JournalEntry struct {
+	Day    int      `json:"day"`
+	X      int      `json:"x"`
+	Y      int      `json:"y"`
+	Author string   `json:"author"`
+	Kind   string   `json:"kind"`
+	Text   string   `json:"text"`
+	Prompt string   `json:"prompt,omitempty"` // the journaling prompt a player entry answers
+	Route  [][2]int `json:"route,omitempty"`  // the tiles fast travel walked, from where it set out
+}
+
+// String formats the entry the way the journal prints it.
+func (e JournalEntry) String() string {
+	switch {
+	case e.Kind == EntryItem:
+		return "  → Found: " + e.Text
+	case e.Author == AuthorPlayer:
+		lines := []string{fmt.Sprintf("Day %d ✍️", e.Day)}
+		if e.Prompt != "" {
+			lines = append(lines, "  “"+e.Prompt+"”")
+		}
+		for _, line := range strings.Split(e.Text, "\n") {
+			lines = append(lines, "  "+line)
+		}
+		return strings.Join(lines, "\n")
+	}
+	return fmt.Sprintf("Day %d: %s", e.Day, e.Text)
+}
+
+// logEntry records an engine entry for the current day and tile.
+func (g *Game) logEntry(kind, text string) {
+	g.JournalLog = append(g.JournalLog, JournalEntry{
+		Day:    g.TurnCount,
+		X:      g.CurrentX,
+		Y:      g.CurrentY,
+		Author: AuthorEngine,
+		Kind:   kind,
+		Text:   text,
+	})
+}
+
+// LastEntry returns the most recent journal entry.
+func (g *Game) LastEntry() JournalEntry {
+	if len(g.JournalLog) == 0 {
+		return JournalEntry{}
+	}
+	return g.JournalLog[len(g.JournalLog)-1]
+}
+
+// Write adds the player's own words to the journal for the current day and
+// tile. Prompt is the journaling prompt being answered, if any.
+func (g *Game) Write(text, prompt string) error {
+	_, err := g.Apply(Action{Kind: ActionWrite, Text: text, Prompt: prompt})
+	return err
+}
+
+func (g *Game) write(text, prompt string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("there is nothing to write")
+	}
+
+	g.JournalLog = append(g.JournalLog, JournalEntry{
+		Day:    g.TurnCount,
+		X:      g.CurrentX,
+		Y:      g.CurrentY,
+		Author: AuthorPlayer,
+		Kind:   EntryNote,
+		Text:   text,
+		Prompt: prompt,
+	})
+	return nil
+}
+
+// Reflection returns the journaling prompt offered at the current tile, or
+// "" if the content has none. Each tile always offers the same prompt.
+func (g *Game) Reflection() string {
+	reflections := g.content.Reflections
+	if len(reflections) == 0 {
+		return ""
+	}
+	r := g.tileRand(g.CurrentX, g.CurrentY, "reflection")
+	return reflections[r.Intn(len(reflections))]
+}
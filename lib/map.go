@@ -1,19 +1,22 @@
 package lib
 
 import (
-	"GentleWanderings/lib/printer"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
 type Tile struct {
-	X           int
-	Y           int
-	Theme       string
-	Description string
-	Discovery   string
-	Visited     bool
-	Item        *Item // Optional item found at this location
+	X           int      `json:"x"`
+	Y           int      `json:"y"`
+	Theme       string   `json:"theme"`
+	Description string   `json:"description"`
+	Discovery   string   `json:"discovery"`
+	Visited     bool     `json:"visited"`
+	Biome       string   `json:"biome,omitempty"`    // id of the content biome the tile lies in
+	Features    []string `json:"features,omitempty"` // things prompt cards have added to the tile
+	Item        *Item    `json:"item,omitempty"`     // Optional item found at this location
 }
 
 type Direction struct {
@@ -35,38 +38,90 @@ func (g *Game) GetTile(x, y int) *Tile {
 	return g.Map[g.tileKey(x, y)]
 }
 
-func (g *Game) GetAdjacentDirections() []Direction {
-	directions := []Direction{
-		{Name: "North", DX: 0, DY: 1},
-		{Name: "South", DX: 0, DY: -1},
-		{Name: "East", DX: 1, DY: 0},
-		{Name: "West", DX: -1, DY: 0},
-	}
+// Directions lists the four cardinal directions in prompt order
+var Directions = []Direction{
+	{Name: "North", DX: 0, DY: 1},
+	{Name: "South", DX: 0, DY: -1},
+	{Name: "East", DX: 1, DY: 0},
+	{Name: "West", DX: -1, DY: 0},
+}
 
-	available := []Direction{}
-	for _, dir := range directions {
-		newX, newY := g.CurrentX+dir.DX, g.CurrentY+dir.DY
-		if g.GetTile(newX, newY) == nil {
-			available = append(available, dir)
+// DirectionNamed finds a direction by its name or first letter, in any case.
+func DirectionNamed(name string) (Direction, error) {
+	for _, dir := range Directions {
+		if strings.EqualFold(dir.Name, name) || strings.EqualFold(dir.Name[:1], name) {
+			return dir, nil
 		}
 	}
+	return Direction{}, errors.New("no direction called " + name)
+}
 
-	return available
+// Exit is a direction the player can take from the current tile, along with
+// the tile waiting there (nil if that way is still unexplored)
+type Exit struct {
+	Direction Direction
+	Tile      *Tile
 }
 
-// GenerateLocationOptions creates 3 themed location options for the player
-func (g *Game) GenerateLocationOptions() []LocationOption {
-	themes := []string{
-		"Mushroom Circle", "Mossy Stones", "Babbling Brook", "Wildflower Meadow",
-		"Hollow Tree", "Crystal Pool", "Foggy Hollow", "Sunlit Glade",
-		"Berry Thicket", "Stone Circle", "Whispering Willows", "Hidden Grotto",
-		"Autumn Vale", "Morning Mist", "Starlit Clearing", "Gentle Waterfall",
+// Label describes the exit for a prompt, e.g. "Explore East" or "Return to Mossy Stones"
+func (e Exit) Label() string {
+	if e.Tile != nil {
+		return "Return to " + e.Tile.Theme
 	}
+	return "Explore " + e.Direction.Name
+}
+
+// GetExits returns every direction from the current tile, explored or not
+func (g *Game) GetExits() []Exit {
+	exits := []Exit{}
+	for _, dir := range Directions {
+		exits = append(exits, Exit{
+			Direction: dir,
+			Tile:      g.GetTile(g.CurrentX+dir.DX, g.CurrentY+dir.DY),
+		})
+	}
+	return exits
+}
 
+// Bounds returns the smallest box holding every explored tile
+func (g *Game) Bounds() (minX, maxX, minY, maxY int) {
+	for _, tile := range g.Map {
+		if tile.X < minX {
+			minX = tile.X
+		}
+		if tile.X > maxX {
+			maxX = tile.X
+		}
+		if tile.Y < minY {
+			minY = tile.Y
+		}
+		if tile.Y > maxY {
+			maxY = tile.Y
+		}
+	}
+	return minX, maxX, minY, maxY
+}
+
+// GenerateLocationOptions creates 3 themed location options for the tile at (x, y).
+// The options depend only on the seed and coordinates, not the route taken.
+func (g *Game) GenerateLocationOptions(x, y int) []LocationOption {
+	r := g.tileRand(x, y, "options")
+
+	themes := g.content.Themes
 	descriptors := [][]string{
-		{"ancient", "forgotten", "peaceful", "mysterious", "enchanted"},
-		{"soft light dances across", "shadows play among", "gentle sounds echo from", "a strange calm pervades"},
-		{"You feel drawn here", "Something calls to you", "A sense of wonder fills you", "Time seems to slow"},
+		g.content.Descriptors.Adjectives,
+		g.content.Descriptors.Details,
+		g.content.Descriptors.Feelings,
+	}
+
+	// Regions draw from their biome's own tables where it has them
+	if biome := g.BiomeAt(x, y); biome != nil {
+		themes = biome.Themes
+		for i, table := range [][]string{biome.Descriptors.Adjectives, biome.Descriptors.Details, biome.Descriptors.Feelings} {
+			if len(table) > 0 {
+				descriptors[i] = table
+			}
+		}
 	}
 
 	options := []LocationOption{}
@@ -75,16 +130,16 @@ func (g *Game) GenerateLocationOptions() []LocationOption {
 	for i := 0; i < 3; i++ {
 		var theme string
 		for {
-			theme = themes[g.rand.Intn(len(themes))]
+			theme = themes[r.Intn(len(themes))]
 			if !usedThemes[theme] {
 				usedThemes[theme] = true
 				break
 			}
 		}
 
-		adj := descriptors[0][g.rand.Intn(len(descriptors[0]))]
-		detail := descriptors[1][g.rand.Intn(len(descriptors[1]))]
-		feeling := descriptors[2][g.rand.Intn(len(descriptors[2]))]
+		adj := descriptors[0][r.Intn(len(descriptors[0]))]
+		detail := descriptors[1][r.Intn(len(descriptors[1]))]
+		feeling := descriptors[2][r.Intn(len(descriptors[2]))]
 
 		options = append(options, LocationOption{
 			Theme:       theme,
@@ -95,129 +150,141 @@ func (g *Game) GenerateLocationOptions() []LocationOption {
 	return options
 }
 
-// ShowMap displays an enhanced map with box-drawing characters
-func (g *Game) ShowMap() {
-	minX, maxX := 0, 0
-	minY, maxY := 0, 0
+// MapCell is what one square of the map shows
+type MapCell int
 
-	for _, tile := range g.Map {
-		if tile.X < minX {
-			minX = tile.X
-		}
-		if tile.X > maxX {
-			maxX = tile.X
-		}
-		if tile.Y < minY {
-			minY = tile.Y
-		}
-		if tile.Y > maxY {
-			maxY = tile.Y
-		}
+const (
+	CellFog      MapCell = iota // unknown land beyond the frontier
+	CellFrontier                // unexplored, but next to somewhere explored
+	CellExplored
+	CellItem  // explored, with an item found there
+	CellRoute // explored, on a planned route
+	CellPlayer
+)
+
+// MapGrid is part of the map, ready to draw. Each cell covers Scale by Scale
+// tiles and shows the most notable of them. Cells run north to south, then
+// west to east.
+type MapGrid struct {
+	MinX, MaxX, MinY, MaxY int // the tiles on screen
+	Scale                  int
+	Cells                  [][]MapCell
+	Tiles                  [][]*Tile // the explored tile each cell shows, if any
+	North, South           []bool    // per column, whether explored land continues off screen
+	West, East             []bool    // per row, likewise
+}
+
+// MapGrid works out what each square of the map shows, highlighting route.
+// It covers every explored tile with a one-tile frontier around them.
+func (g *Game) MapGrid(route []*Tile) *MapGrid {
+	minX, maxX, minY, maxY := g.Bounds()
+	return g.mapWindow(minX-1, maxX+1, minY-1, maxY+1, 1, route)
+}
+
+// mapWindow builds the grid for the tiles from (minX, maxY) to (maxX, minY),
+// which must span a whole number of cells.
+func (g *Game) mapWindow(minX, maxX, minY, maxY, scale int, route []*Tile) *MapGrid {
+	onRoute := map[*Tile]bool{}
+	for _, tile := range route {
+		onRoute[tile] = true
 	}
 
-	// Add padding
-	minX--
-	maxX++
-	minY--
-	maxY++
-
-	width := maxX - minX + 1
-
-	fmt.Println()
-	fmt.Println("╔" + strings.Repeat("═", width*4-1) + "╗")
-	fmt.Println("║" + printer.CenterText("Your Map", width*4-1) + "║")
-	fmt.Println("╠" + strings.Repeat("═", width*4-1) + "╣")
-
-	for y := maxY; y >= minY; y-- {
-		line := "║ "
-		for x := minX; x <= maxX; x++ {
-			tile := g.GetTile(x, y)
-			if tile != nil {
-				if x == g.CurrentX && y == g.CurrentY {
-					line += "📍"
-				} else if tile.Item != nil {
-					line += "🎁"
-				} else {
-					line += "■ "
-				}
-			} else {
-				// Check if there's an adjacent explored tile
-				hasAdjacent := false
-				for _, d := range []struct{ dx, dy int }{{0, 1}, {0, -1}, {1, 0}, {-1, 0}} {
-					if g.GetTile(x+d.dx, y+d.dy) != nil {
-						hasAdjacent = true
-						break
+	grid := &MapGrid{MinX: minX, MaxX: maxX, MinY: minY, MaxY: maxY, Scale: scale}
+	cols := (maxX - minX + 1) / scale
+	rows := (maxY - minY + 1) / scale
+	for row := 0; row < rows; row++ {
+		cells := make([]MapCell, cols)
+		tiles := make([]*Tile, cols)
+		for col := 0; col < cols; col++ {
+			left, top := minX+col*scale, maxY-row*scale
+			for y := top; y > top-scale; y-- {
+				for x := left; x < left+scale; x++ {
+					if cell := g.mapCell(x, y, onRoute); cell > cells[col] || tiles[col] == nil && cell >= CellExplored {
+						cells[col] = cell
+						tiles[col] = g.GetTile(x, y)
 					}
 				}
-				if hasAdjacent {
-					line += "· "
-				} else {
-					line += "  "
-				}
 			}
-			line += " "
 		}
-		fmt.Println(line + "║")
+		grid.Cells = append(grid.Cells, cells)
+		grid.Tiles = append(grid.Tiles, tiles)
 	}
 
-	fmt.Println("╚" + strings.Repeat("═", width*4-1) + "╝")
-	fmt.Println()
-	fmt.Println("Legend: 📍 You  ■ Explored  🎁 Has Item  · Unexplored")
-	fmt.Println()
-}
-
-// ShowDetailedMap shows the map with location names
-func (g *Game) ShowDetailedMap() {
-	minX, maxX := 0, 0
-	minY, maxY := 0, 0
-
+	// Note which edges have explored land beyond them
+	grid.North, grid.South = make([]bool, cols), make([]bool, cols)
+	grid.West, grid.East = make([]bool, rows), make([]bool, rows)
 	for _, tile := range g.Map {
-		if tile.X < minX {
-			minX = tile.X
+		col := floorDiv(tile.X-minX, scale)
+		row := floorDiv(maxY-tile.Y, scale)
+		switch {
+		case col >= 0 && col < cols && tile.Y > maxY:
+			grid.North[col] = true
+		case col >= 0 && col < cols && tile.Y < minY:
+			grid.South[col] = true
 		}
-		if tile.X > maxX {
-			maxX = tile.X
-		}
-		if tile.Y < minY {
-			minY = tile.Y
-		}
-		if tile.Y > maxY {
-			maxY = tile.Y
+		switch {
+		case row >= 0 && row < rows && tile.X < minX:
+			grid.West[row] = true
+		case row >= 0 && row < rows && tile.X > maxX:
+			grid.East[row] = true
 		}
 	}
+	return grid
+}
 
-	fmt.Println()
-	fmt.Println("╔════════════════════════════════════════════════════════════╗")
-	fmt.Println("║" + printer.CenterText("Detailed Map", 60) + "║")
-	fmt.Println("╚════════════════════════════════════════════════════════════╝")
-	fmt.Println()
-
-	// List all locations
-	locations := []*Tile{}
-	for _, tile := range g.Map {
-		locations = append(locations, tile)
+func (g *Game) mapCell(x, y int, onRoute map[*Tile]bool) MapCell {
+	tile := g.GetTile(x, y)
+	switch {
+	case tile != nil && x == g.CurrentX && y == g.CurrentY:
+		return CellPlayer
+	case tile != nil && onRoute[tile]:
+		return CellRoute
+	case tile != nil && tile.Item != nil:
+		return CellItem
+	case tile != nil:
+		return CellExplored
 	}
 
-	// Sort by turn order (Y then X for visual consistency)
-	for i := 0; i < len(locations); i++ {
-		for j := i + 1; j < len(locations); j++ {
-			if locations[i].Y < locations[j].Y || (locations[i].Y == locations[j].Y && locations[i].X > locations[j].X) {
-				locations[i], locations[j] = locations[j], locations[i]
+	// The frontier rings the explored land, corners included
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if g.GetTile(x+dx, y+dy) != nil {
+				return CellFrontier
 			}
 		}
 	}
+	return CellFog
+}
 
-	for _, tile := range locations {
-		marker := "■"
-		if tile.X == g.CurrentX && tile.Y == g.CurrentY {
-			marker = "📍"
-		}
+// MapWindow returns the part of the map that fits in cols by rows cells,
+// around the player or wherever the map has been panned to
+func (g *Game) MapWindow(cols, rows int) *MapGrid {
+	return g.view.window(g, cols, rows)
+}
 
-		pos := fmt.Sprintf("(%d,%d)", tile.X, tile.Y)
-		fmt.Printf("%s %s %s\n", marker, tile.Theme, pos)
-		if tile.Item != nil {
-			fmt.Printf("   🎁 Contains: %s\n", tile.Item.Name)
-		}
+// RouteWindow returns the map zoomed to fit a planned route in cols by rows
+// cells, with the route highlighted
+func (g *Game) RouteWindow(route []*Tile, cols, rows int) *MapGrid {
+	view := mapView{fit: true, route: route}
+	return view.window(g, cols, rows)
+}
+
+// Tiles returns every explored tile from north to south, then west to east
+func (g *Game) Tiles() []*Tile {
+	tiles := make([]*Tile, 0, len(g.Map))
+	for _, tile := range g.Map {
+		tiles = append(tiles, tile)
 	}
-	fmt.Println()
+	sort.Slice(tiles, func(i, j int) bool {
+		if tiles[i].Y != tiles[j].Y {
+			return tiles[i].Y > tiles[j].Y
+		}
+		return tiles[i].X < tiles[j].X
+	})
+	return tiles
+}
+
+// CurrentTile returns the tile the player is standing on
+func (g *Game) CurrentTile() *Tile {
+	return g.GetTile(g.CurrentX, g.CurrentY)
 }
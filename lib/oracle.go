@@ -0,0 +1,162 @@
+package lib
+
+import (
+	"GentleWanderings/lib/content"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxRollDepth stops nested {roll:...} references from expanding forever.
+const maxRollDepth = 8
+
+// Prompt is a prompt card drawn on arrival, with its rolls already made.
+type Prompt struct {
+	Text    string
+	Choices []string
+	card    *content.Prompt
+}
+
+// Roll picks a weighted answer from the named oracle table, expanding any
+// rolls nested inside it. It uses the game's random state, so rolls are
+// reproducible from a save.
+func (g *Game) Roll(table string) string {
+	return g.roll(table, 0)
+}
+
+func (g *Game) roll(table string, depth int) string {
+	entries := g.content.Tables[table]
+	if len(entries) == 0 {
+		return ""
+	}
+
+	total := 0
+	for _, entry := range entries {
+		total += entry.Odds()
+	}
+	pick := g.rand.Intn(total)
+	for _, entry := range entries {
+		pick -= entry.Odds()
+		if pick < 0 {
+			return g.expand(entry.Text, depth+1)
+		}
+	}
+	return ""
+}
+
+// Expand replaces every {roll:table} in text with a roll on that table.
+func (g *Game) Expand(text string) string {
+	return g.expand(text, 0)
+}
+
+func (g *Game) expand(text string, depth int) string {
+	if depth > maxRollDepth {
+		return text
+	}
+	return content.RollRef.ReplaceAllStringFunc(text, func(ref string) string {
+		table := content.RollRef.FindStringSubmatch(ref)[1]
+		return g.roll(table, depth)
+	})
+}
+
+// DrawPrompt may draw a prompt card for the current tile. It returns nil
+// when no card turns up.
+func (g *Game) DrawPrompt() *Prompt {
+	out, _ := g.Apply(Action{Kind: ActionDraw})
+	return out.Prompt
+}
+
+func (g *Game) drawPrompt() *Prompt {
+	g.prompt = nil
+	tile := g.GetTile(g.CurrentX, g.CurrentY)
+	if tile == nil || g.rand.Float64() >= g.content.PromptOdds() {
+		return nil
+	}
+
+	// Only cards meant for this biome (or anywhere) can be drawn
+	cards := []*content.Prompt{}
+	for i := range g.content.Prompts {
+		card := &g.content.Prompts[i]
+		if len(card.Biomes) == 0 {
+			cards = append(cards, card)
+			continue
+		}
+		for _, biome := range card.Biomes {
+			if biome == tile.Biome {
+				cards = append(cards, card)
+				break
+			}
+		}
+	}
+	if len(cards) == 0 {
+		return nil
+	}
+
+	card := cards[g.rand.Intn(len(cards))]
+	prompt := &Prompt{Text: g.Expand(card.Text), card: card}
+	for _, choice := range card.Choices {
+		prompt.Choices = append(prompt.Choices, g.Expand(choice.Text))
+	}
+	g.prompt = prompt
+	return prompt
+}
+
+// PendingPrompt returns the card drawn and not yet answered, or nil.
+func (g *Game) PendingPrompt() *Prompt {
+	return g.prompt
+}
+
+// ResolvePrompt applies the outcome of the chosen answer (numbered from 0):
+// it writes to the journal and may grant an item or add a feature to the
+// current tile. It returns the journal text and any item found. Only the
+// card drawn last can be answered, and only once.
+func (g *Game) ResolvePrompt(p *Prompt, choice int) (string, *Item, error) {
+	if p == nil || p != g.prompt {
+		return "", nil, errors.New("that card is no longer in play")
+	}
+	out, err := g.Apply(Action{Kind: ActionAnswer, Choice: choice})
+	if err != nil {
+		return "", nil, err
+	}
+	return out.Text, out.Item, nil
+}
+
+func (g *Game) resolvePrompt(choice int) (string, *Item, error) {
+	p := g.prompt
+	if p == nil {
+		return "", nil, errors.New("there is no card to answer")
+	}
+	if choice < 0 || choice >= len(p.card.Choices) {
+		return "", nil, fmt.Errorf("choose an answer between 1 and %d", len(p.card.Choices))
+	}
+	outcome := p.card.Choices[choice]
+	tile := g.GetTile(g.CurrentX, g.CurrentY)
+	g.prompt = nil
+
+	text := g.Expand(outcome.Journal)
+	g.logEntry(EntryOracle, text)
+
+	if outcome.Feature != "" {
+		tile.Features = append(tile.Features, g.Expand(outcome.Feature))
+	}
+
+	var item *Item
+	if cat := g.content.Category(outcome.Item); cat != nil {
+		item = &Item{
+			Name:        cat.Names[g.rand.Intn(len(cat.Names))],
+			Description: cat.Descriptions[g.rand.Intn(len(cat.Descriptions))],
+			Category:    cat.ID,
+			FoundAt:     tile.Theme,
+			FoundDay:    g.TurnCount,
+		}
+		g.Inventory = append(g.Inventory, item)
+		g.logEntry(EntryItem, item.Name)
+
+		// The map marks where things were found, the first thing at most
+		if tile.Item == nil {
+			tile.Item = item
+		}
+	}
+
+	return strings.TrimSpace(text), item, nil
+}
@@ -0,0 +1,79 @@
+package lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// drawCard wanders north from seed until a card turns up, giving up after
+// a few seeds.
+func drawCard(t *testing.T, want func(p *Prompt) bool) (*Game, *Prompt) {
+	t.Helper()
+	for seed := int64(1); seed < 500; seed++ {
+		g := NewGameWithSeed(seed)
+		wander(t, g, "North")
+		if p := g.DrawPrompt(); p != nil && want(p) {
+			return g, p
+		}
+	}
+	t.Fatal("no card turned up")
+	return nil, nil
+}
+
+// grants returns the first answer to p that grants an item, or -1.
+func grants(p *Prompt) int {
+	for i, choice := range p.card.Choices {
+		if choice.Item != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestPromptItemMarksTile(t *testing.T) {
+	g, p := drawCard(t, func(p *Prompt) bool { return grants(p) >= 0 })
+	tile := g.CurrentTile()
+	before := tile.Item
+
+	_, item, err := g.ResolvePrompt(p, grants(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item == nil {
+		t.Fatal("the answer granted no item")
+	}
+	if before == nil && tile.Item != item {
+		t.Errorf("tile holds %v, want %v", tile.Item, item)
+	}
+	if before != nil && tile.Item != before {
+		t.Errorf("tile holds %v, want the %v found exploring", tile.Item, before)
+	}
+}
+
+func TestSavePendingPrompt(t *testing.T) {
+	g, p := drawCard(t, func(*Prompt) bool { return true })
+
+	loaded := roundTrip(t, g)
+	pending := loaded.PendingPrompt()
+	if pending == nil {
+		t.Fatal("the card was lost saving")
+	}
+	if pending.Text != p.Text || !reflect.DeepEqual(pending.Choices, p.Choices) {
+		t.Fatalf("loaded card %q %q, want %q %q", pending.Text, pending.Choices, p.Text, p.Choices)
+	}
+
+	// Answering it after loading turns out as it would have before
+	if _, _, err := g.ResolvePrompt(p, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := loaded.ResolvePrompt(pending, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.JournalLog, g.JournalLog) || !reflect.DeepEqual(loaded.Inventory, g.Inventory) {
+		t.Errorf("answering after loading wrote %v and found %v, want %v and %v",
+			loaded.JournalLog, loaded.Inventory, g.JournalLog, g.Inventory)
+	}
+	if loaded.PendingPrompt() != nil {
+		t.Error("the card can be answered twice")
+	}
+}
@@ -43,3 +43,13 @@ func ShowInventory() {
 
 	PrintToConsole(inventory)
 }
+
+func ShowSlots() {
+	slots := fmt.Sprintf(`
+╔════════════════════════════════════════════════════════════╗
+║%s║
+╚════════════════════════════════════════════════════════════╝
+`, CenterText("Save Slots", 60))
+
+	PrintToConsole(slots)
+}
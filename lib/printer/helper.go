@@ -2,7 +2,11 @@ package printer
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+
+	"golang.org/x/term"
 )
 
 /*
@@ -12,14 +16,198 @@ terminal.
 
 // CenterText takes a text string and a width integer, and centers the text within the specified width by padding with spaces.
 func CenterText(text string, width int) string {
-	if len(text) >= width {
+	textWidth := DisplayWidth(text)
+	if textWidth >= width {
 		return text
 	}
-	leftPad := (width - len(text)) / 2
-	rightPad := width - len(text) - leftPad
+	leftPad := (width - textWidth) / 2
+	rightPad := width - textWidth - leftPad
 	return strings.Repeat(" ", leftPad) + text + strings.Repeat(" ", rightPad)
 }
 
+// TerminalSize returns the width and height of the terminal in characters.
+// When output is not a terminal it falls back to $COLUMNS and $LINES, and
+// then to 80x24.
+func TerminalSize() (width, height int) {
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 && h > 0 {
+		return w, h
+	}
+	width, height = 80, 24
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		width = w
+	}
+	if h, err := strconv.Atoi(os.Getenv("LINES")); err == nil && h > 0 {
+		height = h
+	}
+	return width, height
+}
+
+// PadRight pads text with spaces until it fills width terminal columns.
+func PadRight(text string, width int) string {
+	if pad := width - DisplayWidth(text); pad > 0 {
+		return text + strings.Repeat(" ", pad)
+	}
+	return text
+}
+
+// DisplayWidth returns how many terminal columns text takes up. Colour codes
+// take none, and emoji and other wide characters take two.
+func DisplayWidth(text string) int {
+	width := 0
+	for _, cell := range cells(text) {
+		width += cell.width
+	}
+	return width
+}
+
+// Slice returns the part of text between two terminal columns, keeping its
+// colour codes. A wide character cut in half becomes a space.
+func Slice(text string, start, end int) string {
+	var b strings.Builder
+	col := 0
+	coloured := false
+	for _, cell := range cells(text) {
+		switch {
+		case cell.width == 0:
+			b.WriteString(cell.text)
+			coloured = true
+		case col >= start && col+cell.width <= end:
+			b.WriteString(cell.text)
+		case col < end && col+cell.width > start:
+			// Only part of a wide character fits
+			b.WriteString(strings.Repeat(" ", min(col+cell.width, end)-max(col, start)))
+		}
+		col += cell.width
+	}
+	if coloured {
+		b.WriteString("\033[0m")
+	}
+	return b.String()
+}
+
+// Fit cuts or pads text to exactly width terminal columns.
+func Fit(text string, width int) string {
+	return PadRight(Slice(text, 0, width), width)
+}
+
+// Wrap breaks text into lines no wider than width, at spaces where it can.
+// Existing line breaks are kept.
+func Wrap(text string, width int) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for DisplayWidth(word) > width {
+				// Words longer than a whole line are split, between
+				// characters so a wide one is not cut in half
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				split := fits(word, width)
+				if split == 0 {
+					split = width
+				}
+				lines = append(lines, Slice(word, 0, split))
+				word = Slice(word, split, DisplayWidth(word))
+			}
+			switch {
+			case line == "":
+				line = word
+			case DisplayWidth(line)+1+DisplayWidth(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fits returns how many columns of whole characters from the start of text
+// fit in width.
+func fits(text string, width int) int {
+	col := 0
+	for _, cell := range cells(text) {
+		if col+cell.width > width {
+			break
+		}
+		col += cell.width
+	}
+	return col
+}
+
+// cell is one character as the terminal draws it: a rune with any marks
+// joined to it, or a colour code with no width.
+type cell struct {
+	text  string
+	width int
+}
+
+func cells(text string) []cell {
+	out := []cell{}
+	escape := -1 // where a colour code began
+	for i, r := range text {
+		switch {
+		case escape >= 0:
+			// Colour codes end with a letter, e.g. \033[38;2;1;2;3m
+			if r >= '@' && r <= '~' && r != '[' {
+				out = append(out, cell{text: text[escape : i+1]})
+				escape = -1
+			}
+			continue
+		case r == '\033':
+			escape = i
+			continue
+		case r == '\uFE0F' || r == '\u200D' || r == '\uFE0E' || (r >= 0x300 && r <= 0x36F):
+			if n := len(out); n > 0 && out[n-1].width > 0 {
+				out[n-1].text += string(r)
+				// Emoji presentation turns a narrow symbol into a wide one
+				if r == '\uFE0F' {
+					out[n-1].width = 2
+				}
+			}
+			continue
+		}
+
+		width := 1
+		if isWide(r) {
+			width = 2
+		}
+		out = append(out, cell{text: string(r), width: width})
+	}
+	return out
+}
+
+// wideRanges are the blocks of characters terminals draw two columns wide.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0},
+	{0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653},
+	{0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB},
+	{0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE}, {0x26D4, 0x26D4},
+	{0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5}, {0x26FA, 0x26FA},
+	{0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757},
+	{0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E}, {0x3041, 0x33FF},
+	{0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF}, {0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF}, {0xFE30, 0xFE4F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A},
+	{0x1F200, 0x1F251}, {0x1F300, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB},
+	{0x1F900, 0x1F9FF}, {0x1FA70, 0x1FAFF}, {0x20000, 0x3FFFD},
+}
+
+func isWide(r rune) bool {
+	for _, span := range wideRanges {
+		if r >= span[0] && r <= span[1] {
+			return true
+		}
+	}
+	return false
+}
+
 // PrintToConsole takes a message string and prints it to the console after clearing the terminal screen
 // Everything to feed the print related output to this function to ensure the scren is cleared.
 func PrintToConsole(message string) {
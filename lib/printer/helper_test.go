@@ -0,0 +1,71 @@
+package printer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	for text, want := range map[string]int{
+		"Moss":                        4,
+		"🎁":                           2,
+		"❤️":                          2, // a narrow heart made an emoji
+		"☺︎":                          1, // a smile kept as text
+		"日本語":                         6,
+		"Cafe\u0301":                  4, // the accent joins the e
+		"\033[38;2;1;2;3m■\033[0m ok": 4,
+		"📍 Position":                  11,
+	} {
+		if got := DisplayWidth(text); got != want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestSlice(t *testing.T) {
+	tests := []struct {
+		text       string
+		start, end int
+		want       string
+	}{
+		{"Mossy stones", 0, 5, "Mossy"},
+		{"日本語", 2, 4, "本"},
+		{"日本語", 1, 4, " 本"}, // half of 日 becomes a space
+		{"a🎁b", 0, 2, "a "},
+		{"❤️ love", 0, 2, "❤️"},
+		{"Cafe\u0301!", 3, 5, "e\u0301!"},
+		{"\033[1m日本\033[0m", 0, 2, "\033[1m日\033[0m\033[0m"},
+	}
+	for _, tt := range tests {
+		if got := Slice(tt.text, tt.start, tt.end); got != tt.want {
+			t.Errorf("Slice(%q, %d, %d) = %q, want %q", tt.text, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"a quiet mossy glade", 8, []string{"a quiet", "mossy", "glade"}},
+		{"first\n\nsecond", 20, []string{"first", "", "second"}},
+		{"🎁 found 🎁 here", 8, []string{"🎁 found", "🎁 here"}},
+		{"日本語 日本語", 7, []string{"日本語", "日本語"}},
+		// A wide character that does not fit moves to the next line whole
+		{"日本語日本語", 5, []string{"日本", "語日", "本語"}},
+		{"❤️❤️❤️", 3, []string{"❤️", "❤️", "❤️"}},
+	}
+	for _, tt := range tests {
+		got := Wrap(tt.text, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+		for _, line := range got {
+			if DisplayWidth(line) > tt.width {
+				t.Errorf("Wrap(%q, %d) has a line %q %d wide", tt.text, tt.width, line, DisplayWidth(line))
+			}
+		}
+	}
+}
@@ -1 +1,35 @@
 package printer
+
+import (
+	"GentleWanderings/lib"
+	"fmt"
+	"strings"
+)
+
+// ShowInventory displays the player's collected items
+func (t *Terminal) ShowInventory(g *lib.Game) {
+	ShowInventory()
+
+	if len(g.Inventory) == 0 {
+		fmt.Println("\nYour pack is empty. Perhaps you'll find something as you wander...")
+		fmt.Println()
+		return
+	}
+
+	for _, group := range g.InventoryGroups() {
+		fmt.Printf("\n%s (%d)\n", group.Label, len(group.Items))
+		fmt.Println(strings.Repeat("─", 60))
+
+		for i, item := range group.Items {
+			fmt.Printf("%d. %s\n", i+1, item.Name)
+			fmt.Printf("   %s\n", item.Description)
+			fmt.Printf("   Found at %s on Day %d\n", item.FoundAt, item.FoundDay)
+			if i < len(group.Items)-1 {
+				fmt.Println()
+			}
+		}
+	}
+
+	fmt.Printf("\n%s Total items collected: %d\n", strings.Repeat("─", 60), len(g.Inventory))
+	fmt.Println()
+}
@@ -1,18 +1,203 @@
 package printer
 
 import (
+	"GentleWanderings/lib"
 	"fmt"
 	"strings"
 )
 
-func ShowMap(width int) {
-	mapRender := fmt.Sprintf(`
-╔%s╗
-║%s║
-╠%s╣
-`, strings.Repeat("═", width*4-1),
-		CenterText("Map", width*4-1),
-		strings.Repeat("═", width*4-1))
+// mapGlyphs are the marks for each kind of cell, with emoji or in plain ASCII
+var mapGlyphs = map[bool]map[lib.MapCell]string{
+	false: {lib.CellFog: "#", lib.CellFrontier: "·", lib.CellExplored: "■", lib.CellItem: "🎁", lib.CellRoute: "◆", lib.CellPlayer: "📍"},
+	true:  {lib.CellFog: "#", lib.CellFrontier: ".", lib.CellExplored: "o", lib.CellItem: "+", lib.CellRoute: "*", lib.CellPlayer: "@"},
+}
+
+// ShowMap displays the part of the map around the player that fits in the
+// terminal, or wherever it has been panned to
+func (t *Terminal) ShowMap(g *lib.Game) {
+	cols, rows := mapScreenSize()
+	t.showGrid(g, g.MapWindow(cols, rows), nil)
+}
+
+// ShowRoute displays the map with a planned route highlighted, zoomed to fit
+// the whole route on screen
+func (t *Terminal) ShowRoute(g *lib.Game, route []*lib.Tile) {
+	cols, rows := mapScreenSize()
+	t.showGrid(g, g.RouteWindow(route, cols, rows), route)
+}
+
+// mapScreenSize is how many map cells fit in the terminal, leaving room for
+// the box, legend and prompt
+func mapScreenSize() (cols, rows int) {
+	width, height := TerminalSize()
+	return max((width-3)/3, 3), max(height-14, 3)
+}
+
+func (t *Terminal) showGrid(g *lib.Game, grid *lib.MapGrid, route []*lib.Tile) {
+	fmt.Println()
+	for _, line := range t.mapBox(g, grid) {
+		fmt.Println(line)
+	}
+	fmt.Println()
+	for _, line := range t.mapLegend(g, grid, route) {
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+// mapBox draws the grid in a titled box, with arrows on the border where
+// explored land continues off screen
+func (t *Terminal) mapBox(g *lib.Game, grid *lib.MapGrid) []string {
+	glyphs := mapGlyphs[t.ASCII]
+	box := []string{"╔", "═", "╗", "║", "╠", "╣", "╚", "╝"}
+	arrows := []string{"▲", "▼", "◀", "▶"}
+	if t.ASCII {
+		box = []string{"+", "-", "+", "|", "+", "+", "+", "+"}
+		arrows = []string{"^", "v", "<", ">"}
+	}
+
+	title := "Your Map"
+	if grid.Scale > 1 {
+		title = fmt.Sprintf("Your Map (1:%d)", grid.Scale)
+	}
+
+	// Every cell is two columns wide whatever its glyph, with a space between
+	cols := len(grid.Cells[0])
+	gridWidth := cols*3 - 1
+	width := max(DisplayWidth(title)+2, gridWidth+2)
+	left := (width - gridWidth) / 2
+
+	// edge draws a border rule with an arrow over each column that has
+	// explored land beyond it
+	edge := func(open []bool, arrow string) string {
+		rule := make([]string, width)
+		for i := range rule {
+			rule[i] = box[1]
+		}
+		for col, more := range open {
+			if more {
+				rule[left+col*3] = arrow
+			}
+		}
+		return strings.Join(rule, "")
+	}
+
+	lines := []string{
+		box[0] + strings.Repeat(box[1], width) + box[2],
+		box[3] + CenterText(title, width) + box[3],
+		box[4] + edge(grid.North, arrows[0]) + box[5],
+	}
+	for row, cells := range grid.Cells {
+		line := []string{}
+		for col, cell := range cells {
+			glyph := PadRight(glyphs[cell], 2)
+			if cell == lib.CellExplored {
+				glyph = t.tint(g, grid.Tiles[row][col], glyph)
+			}
+			line = append(line, glyph)
+		}
+
+		west, east := box[3], box[3]
+		if grid.West[row] {
+			west = arrows[2]
+		}
+		if grid.East[row] {
+			east = arrows[3]
+		}
+		lines = append(lines, west+PadRight(strings.Repeat(" ", left)+strings.Join(line, " "), width)+east)
+	}
+	return append(lines, box[6]+edge(grid.South, arrows[1])+box[7])
+}
+
+// mapLegend explains the map's marks and colours
+func (t *Terminal) mapLegend(g *lib.Game, grid *lib.MapGrid, route []*lib.Tile) []string {
+	glyphs := mapGlyphs[t.ASCII]
+	legend := []lib.MapCell{lib.CellPlayer, lib.CellRoute, lib.CellExplored, lib.CellItem, lib.CellFrontier, lib.CellFog}
+	labels := map[lib.MapCell]string{
+		lib.CellPlayer: "You", lib.CellRoute: "Route", lib.CellExplored: "Explored",
+		lib.CellItem: "Has Item", lib.CellFrontier: "Unexplored", lib.CellFog: "Fog",
+	}
+	keys := []string{}
+	for _, cell := range legend {
+		if cell == lib.CellRoute && len(route) == 0 {
+			continue
+		}
+		keys = append(keys, glyphs[cell]+" "+labels[cell])
+	}
+
+	lines := []string{"Legend: " + strings.Join(keys, "  ")}
+	if biomes := t.biomeLegend(g); biomes != "" {
+		lines = append(lines, "Biomes: "+biomes)
+	}
+	if grid.Scale > 1 || hasAny(grid.North, grid.South, grid.West, grid.East) {
+		lines = append(lines, "Scroll with map n/s/e/w, map fit to see everything, map here to return to you.")
+	}
+	return lines
+}
+
+func hasAny(edges ...[]bool) bool {
+	for _, edge := range edges {
+		for _, more := range edge {
+			if more {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// biomeLegend lists the colour of each biome found so far
+func (t *Terminal) biomeLegend(g *lib.Game) string {
+	legend := []string{}
+	for _, biome := range g.FoundBiomes() {
+		r, gr, b := biome.RGB()
+		legend = append(legend, t.colour(r, gr, b, mapGlyphs[t.ASCII][lib.CellExplored])+" "+biome.Name)
+	}
+	return strings.Join(legend, "  ")
+}
+
+// ShowDetailedMap shows the map with location names
+func (t *Terminal) ShowDetailedMap(g *lib.Game) {
+	fmt.Println()
+	fmt.Println("╔════════════════════════════════════════════════════════════╗")
+	fmt.Println("║" + CenterText("Detailed Map", 60) + "║")
+	fmt.Println("╚════════════════════════════════════════════════════════════╝")
+	fmt.Println()
+
+	for _, tile := range g.Tiles() {
+		marker := t.tint(g, tile, mapGlyphs[t.ASCII][lib.CellExplored])
+		if tile.X == g.CurrentX && tile.Y == g.CurrentY {
+			marker = mapGlyphs[t.ASCII][lib.CellPlayer]
+		}
+
+		pos := fmt.Sprintf("(%d,%d)", tile.X, tile.Y)
+		if biome := g.TileBiome(tile); biome != nil {
+			fmt.Printf("%s %s %s [%s]\n", marker, tile.Theme, pos, biome.Name)
+		} else {
+			fmt.Printf("%s %s %s\n", marker, tile.Theme, pos)
+		}
+		if tile.Item != nil {
+			fmt.Printf("   🎁 Contains: %s\n", tile.Item.Name)
+		}
+	}
+	fmt.Println()
+}
+
+// tint wraps text in a 24-bit terminal colour matching the tile's biome.
+func (t *Terminal) tint(g *lib.Game, tile *lib.Tile, text string) string {
+	biome := g.TileBiome(tile)
+	if biome == nil {
+		return text
+	}
+	r, gr, b := biome.RGB()
+	return t.colour(r, gr, b, text)
+}
 
-	PrintToConsole(mapRender)
+// colour wraps text in a 24-bit terminal colour, unless the terminal is
+// drawing in plain ASCII or output is not going to a terminal at all.
+func (t *Terminal) colour(r, g, b uint8, text string) string {
+	if t.ASCII || !t.Colour {
+		return text
+	}
+	return fmt.Sprintf("\033[38;2;%d;%d;%dm%s\033[0m", r, g, b, text)
 }
@@ -0,0 +1,57 @@
+package printer
+
+import (
+	"GentleWanderings/lib"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// wandered explores a small loop from seed 42.
+func wandered(t *testing.T) *lib.Game {
+	t.Helper()
+	g := lib.NewGameWithSeed(42)
+	for _, i := range []int{0, 2, 2, 1} {
+		dir := lib.Directions[i]
+		options := g.GenerateLocationOptions(g.CurrentX+dir.DX, g.CurrentY+dir.DY)
+		if _, err := g.Explore(dir, options[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestMapBoxIsSquare(t *testing.T) {
+	g := wandered(t)
+	for _, term := range []*Terminal{{}, {Colour: true}, {ASCII: true}} {
+		for _, grid := range []*lib.MapGrid{g.MapGrid(nil), g.MapWindow(2, 2)} {
+			lines := term.mapBox(g, grid)
+			want := DisplayWidth(lines[0])
+			for i, line := range lines {
+				if got := DisplayWidth(line); got != want {
+					t.Errorf("ascii %v: line %d is %d wide, want %d:\n%s", term.ASCII, i, got, want, strings.Join(lines, "\n"))
+				}
+			}
+		}
+	}
+}
+
+func TestMapColour(t *testing.T) {
+	g := wandered(t)
+	for _, tt := range []struct {
+		term *Terminal
+		want bool
+	}{
+		{&Terminal{Colour: true}, true},
+		{&Terminal{}, false},                          // output is not a terminal
+		{&Terminal{Colour: true, ASCII: true}, false}, // --ascii
+	} {
+		box := strings.Join(tt.term.mapBox(g, g.MapGrid(nil)), "\n")
+		if got := strings.Contains(box, "\033["); got != tt.want {
+			t.Errorf("colour %v, ascii %v: map coloured is %v, want %v", tt.term.Colour, tt.term.ASCII, got, tt.want)
+		}
+		if tt.term.ASCII && strings.ContainsFunc(box, func(r rune) bool { return r > unicode.MaxASCII }) {
+			t.Errorf("ascii map is not plain ASCII:\n%s", box)
+		}
+	}
+}
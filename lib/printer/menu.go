@@ -1,22 +1,199 @@
 package printer
 
-import "fmt"
+import (
+	"GentleWanderings/lib"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// menuItems are the numbered menu entries and the commands they run. Ask is
+// a question whose answer is added to the command, and NoPause skips the
+// "Press Enter" after commands that have their own screen.
+var menuItems = []struct {
+	Label, Command, Ask string
+	NoPause             bool
+}{
+	{Label: "View Map", Command: "map"},
+	{Label: "Detailed Map (with locations)", Command: "places"},
+	{Label: "View Inventory", Command: "inventory"},
+	{Label: "Read Journal", Command: "journal"},
+	{Label: "Current Location Info", Command: "look"},
+	{Label: "Game Statistics", Command: "stats"},
+	{Label: "Save Journey", Command: "save", Ask: "Save to file (" + lib.DefaultSaveFile + "): "},
+	{Label: "Load Journey", Command: "load", Ask: "Load from file (" + lib.DefaultSaveFile + "): "},
+	{Label: "Save Slots", Command: "slots", NoPause: true},
+	{Label: "Return to Journey"},
+}
 
 func ShowMenu() {
+	var lines strings.Builder
+	for i, item := range menuItems {
+		lines.WriteString("║" + PadRight(fmt.Sprintf(" %2d. %s", i+1, item.Label), 60) + "║\n")
+	}
+
 	menu := fmt.Sprintf(`
 ╔════════════════════════════════════════════════════════════╗
 ║%s║
 ╠════════════════════════════════════════════════════════════╣
-║  1. View Map                                               ║
-║  2. Detailed Map (with locations)                          ║
-║  3. View Inventory                                         ║
-║  4. Read Journal                                           ║
-║  5. Current Location Info                                  ║
-║  6. Game Statistics                                        ║
-║  7. Return to Journey                                      ║
-╚════════════════════════════════════════════════════════════╝
+%s╚════════════════════════════════════════════════════════════╝
 
-Choose an option (1-7): `, CenterText("Menu", 60))
+Choose an option (1-%d): `, CenterText("Menu", 60), lines.String(), len(menuItems))
 
 	PrintToConsole(menu)
 }
+
+// ShowMenu displays the main game menu. Each entry runs a command through
+// run, the same way as typing it.
+func (t *Terminal) ShowMenu(run func(line string) error) {
+	for {
+		ShowMenu()
+		if !t.scanner.Scan() {
+			return
+		}
+
+		choice, err := strconv.Atoi(strings.TrimSpace(t.scanner.Text()))
+		if err != nil || choice < 1 || choice > len(menuItems) {
+			fmt.Println("\nInvalid choice. Please try again.")
+			fmt.Print("\nPress Enter to continue...")
+			t.scanner.Scan()
+			continue
+		}
+
+		item := menuItems[choice-1]
+		if item.Command == "" {
+			fmt.Println("\nReturning to your journey...")
+			return
+		}
+
+		line := item.Command
+		if item.Ask != "" {
+			fmt.Print("\n" + item.Ask)
+			if !t.scanner.Scan() {
+				return
+			}
+			line += " " + strings.TrimSpace(t.scanner.Text())
+		}
+		if err := run(line); err != nil {
+			fmt.Printf("\n%v\n", err)
+		}
+		if item.NoPause {
+			continue
+		}
+
+		fmt.Print("\nPress Enter to continue...")
+		t.scanner.Scan()
+	}
+}
+
+// ShowSlots runs the save slot browser, where journeys can be saved into a
+// slot or loaded, renamed, duplicated and deleted.
+func (t *Terminal) ShowSlots(g *lib.Game, slots *lib.Slots) {
+	for {
+		ShowSlots()
+
+		list, err := slots.List()
+		if err != nil {
+			fmt.Printf("Could not read your save slots: %v\n", err)
+			return
+		}
+
+		if len(list) == 0 {
+			fmt.Println("No journeys have been saved yet.")
+		}
+		for i, slot := range list {
+			fmt.Printf("%2d. %s\n", i+1, slot.Name)
+			if slot.Damaged != nil {
+				fmt.Printf("    Damaged: %v\n", slot.Damaged)
+				continue
+			}
+			fmt.Printf("    Day %d · %d locations · last played %s\n",
+				slot.Day, slot.Tiles, slot.LastPlayed.Format("2006-01-02 15:04"))
+		}
+
+		fmt.Println()
+		fmt.Println("[s]ave <name> | [l]oad <n> | [r]ename <n> <name> | [c]opy <n> <name> | [d]elete <n> | [b]ack")
+		fmt.Print("\n> ")
+		if !t.scanner.Scan() {
+			return
+		}
+
+		command, rest, _ := strings.Cut(strings.TrimSpace(t.scanner.Text()), " ")
+		rest = strings.TrimSpace(rest)
+
+		// Every command except save and back starts with a slot number.
+		var slot lib.SlotInfo
+		var name string
+		switch strings.ToLower(command) {
+		case "l", "load", "r", "rename", "c", "copy", "d", "delete":
+			numText, remainder, _ := strings.Cut(rest, " ")
+			n, err := strconv.Atoi(numText)
+			if err != nil || n < 1 || n > len(list) {
+				fmt.Println("\nPlease choose a slot by its number.")
+				fmt.Print("\nPress Enter to continue...")
+				t.scanner.Scan()
+				continue
+			}
+			slot = list[n-1]
+			name = strings.TrimSpace(remainder)
+		}
+
+		switch strings.ToLower(command) {
+		case "s", "save":
+			if slots.Exists(rest) {
+				fmt.Printf("\nOverwrite %q? (y/n): ", rest)
+				if !t.scanner.Scan() || strings.ToLower(strings.TrimSpace(t.scanner.Text())) != "y" {
+					continue
+				}
+			}
+			if err := slots.Save(rest, g); err != nil {
+				fmt.Printf("\nCould not save your journey: %v\n", err)
+			} else {
+				fmt.Printf("\nYour journey has been saved as %q.\n", rest)
+			}
+		case "l", "load":
+			loaded, err := slots.Load(slot.Name)
+			if err != nil {
+				fmt.Printf("\nCould not load %q: %v\n", slot.Name, err)
+				break
+			}
+			g.Replace(loaded)
+			fmt.Printf("\nYour journey %q has been restored. It is Day %d.\n", slot.Name, g.TurnCount)
+			fmt.Print("\nPress Enter to continue...")
+			t.scanner.Scan()
+			return
+		case "r", "rename":
+			if err := slots.Rename(slot.Name, name); err != nil {
+				fmt.Printf("\nCould not rename %q: %v\n", slot.Name, err)
+				break
+			}
+			continue
+		case "c", "copy":
+			if name == "" {
+				name = slot.Name + " copy"
+			}
+			if err := slots.Duplicate(slot.Name, name); err != nil {
+				fmt.Printf("\nCould not copy %q: %v\n", slot.Name, err)
+				break
+			}
+			continue
+		case "d", "delete":
+			fmt.Printf("\nDelete %q forever? (y/n): ", slot.Name)
+			if !t.scanner.Scan() || strings.ToLower(strings.TrimSpace(t.scanner.Text())) != "y" {
+				continue
+			}
+			if err := slots.Delete(slot.Name); err != nil {
+				fmt.Printf("\nCould not delete %q: %v\n", slot.Name, err)
+				break
+			}
+			continue
+		case "b", "back", "":
+			return
+		default:
+			fmt.Println("\nInvalid choice. Please try again.")
+		}
+
+		fmt.Print("\nPress Enter to continue...")
+		t.scanner.Scan()
+	}
+}
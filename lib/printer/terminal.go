@@ -0,0 +1,91 @@
+package printer
+
+import (
+	"GentleWanderings/lib"
+	"bufio"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Terminal is the console front end: it prints the game with box drawing and
+// emoji and reads the player's choices from a scanner.
+type Terminal struct {
+	ASCII   bool // draw maps with plain characters, for terminals without emoji
+	Colour  bool // tint maps with biome colours
+	scanner *bufio.Scanner
+}
+
+var _ lib.View = (*Terminal)(nil)
+
+// NewTerminal creates a terminal front end reading input from scanner.
+// Maps are only coloured when output goes to a terminal.
+func NewTerminal(scanner *bufio.Scanner) *Terminal {
+	return &Terminal{Colour: term.IsTerminal(int(os.Stdout.Fd())), scanner: scanner}
+}
+
+// ShowJournal displays the journey log
+func (t *Terminal) ShowJournal(g *lib.Game) {
+	ShowJournal()
+
+	for _, entry := range g.JournalLog {
+		fmt.Println(entry)
+	}
+	fmt.Println()
+}
+
+// ShowCurrentLocation displays detailed info about current location
+func (t *Terminal) ShowCurrentLocation(g *lib.Game) {
+	tile := g.CurrentTile()
+	if tile == nil {
+		return
+	}
+
+	ShowCurrentLocation()
+
+	fmt.Printf("🌿 %s\n", tile.Theme)
+	fmt.Printf("📍 Position: (%d, %d)\n\n", tile.X, tile.Y)
+	fmt.Printf("%s\n\n", tile.Description)
+
+	for _, feature := range tile.Features {
+		fmt.Printf("✨ Here you'll find %s.\n", feature)
+	}
+	if len(tile.Features) > 0 {
+		fmt.Println()
+	}
+
+	if tile.Item != nil {
+		fmt.Printf("🎁 You found: %s\n", tile.Item.Name)
+		fmt.Printf("   %s\n", tile.Item.Description)
+	} else {
+		fmt.Println("This location holds no items, just peaceful presence.")
+	}
+	fmt.Println()
+}
+
+// ShowStatistics displays game statistics
+func (t *Terminal) ShowStatistics(g *lib.Game) {
+	ShowStatistics()
+
+	fmt.Printf("🌱 World Seed: %d\n", g.Seed())
+	fmt.Printf("🗓️  Days Traveled: %d\n", g.TurnCount)
+	fmt.Printf("🗺️  Locations Discovered: %d\n", len(g.Map))
+	fmt.Printf("🎒 Items Collected: %d\n", len(g.Inventory))
+
+	if groups := g.InventoryGroups(); len(groups) > 0 {
+		fmt.Println("\nCollection breakdown:")
+		for _, group := range groups {
+			fmt.Printf("  %s: %d\n", group.Label, len(group.Items))
+		}
+	}
+
+	// Calculate exploration extent
+	minX, maxX, minY, maxY := g.Bounds()
+	width := maxX - minX + 1
+	height := maxY - minY + 1
+
+	fmt.Printf("\n🧭 Map Dimensions: %d × %d\n", width, height)
+	fmt.Printf("📏 Furthest North: %d, South: %d, East: %d, West: %d\n", maxY, minY, maxX, minX)
+	fmt.Println()
+}
@@ -0,0 +1,369 @@
+package printer
+
+import (
+	"GentleWanderings/lib"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Keys readKey reports by name; anything else is the character typed
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdn"
+	keyCtrlC     = "ctrl-c"
+	keyCtrlS     = "ctrl-s"
+)
+
+// tui is the full-screen front end. The map, the current location and the
+// journal stay on screen, and questions pop up in a box over them.
+type tui struct {
+	term        *Terminal
+	game        *lib.Game
+	reflections bool
+	in          *bufio.Reader
+	out         *bufio.Writer
+
+	scroll    int    // journal lines scrolled back from the newest
+	inventory bool   // show the inventory instead of the journal
+	status    string // a message for the status bar until the next key
+	modal     *modal
+	quit      bool
+}
+
+// modal is a box over the screen that asks the player to pick a choice or
+// type a line. Esc closes it unless cancel is false, for boxes that must be
+// answered.
+type modal struct {
+	title    string
+	body     string
+	choices  []string
+	details  []string // more about each choice, shown under the list
+	selected int
+	asking   bool // reading a line of text instead of a choice
+	input    []rune
+	cancel   bool
+
+	onChoose func(choice int)
+	onText   func(text string)
+}
+
+// RunTUI plays the game full screen until the player quits. Arrow keys or
+// WASD move, and every other key is listed under "?". It needs stdin and
+// stdout to be a terminal; the line mode is still there for everything else.
+func (t *Terminal) RunTUI(g *lib.Game, reflections bool) error {
+	in := int(os.Stdin.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("full-screen mode needs a terminal; leave out --tui to type or pipe commands instead")
+	}
+	state, err := term.MakeRaw(in)
+	if err != nil {
+		return fmt.Errorf("could not switch the terminal to full-screen mode: %w", err)
+	}
+	defer term.Restore(in, state)
+
+	ui := &tui{
+		term:        t,
+		game:        g,
+		reflections: reflections,
+		in:          bufio.NewReader(os.Stdin),
+		out:         bufio.NewWriter(os.Stdout),
+	}
+
+	// Draw on the alternate screen, so the terminal is left as it was
+	ui.out.WriteString("\033[?1049h\033[?25l")
+	defer func() {
+		ui.out.WriteString("\033[?25h\033[?1049l")
+		ui.out.Flush()
+	}()
+
+	for !ui.quit {
+		ui.draw()
+		key, err := ui.readKey()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ui.handle(key)
+	}
+	return nil
+}
+
+// readKey waits for a key press.
+func (ui *tui) readKey() (string, error) {
+	r, _, err := ui.in.ReadRune()
+	if err != nil {
+		return "", err
+	}
+
+	switch r {
+	case 3:
+		return keyCtrlC, nil
+	case 19:
+		return keyCtrlS, nil
+	case '\r', '\n':
+		return keyEnter, nil
+	case 127, 8:
+		return keyBackspace, nil
+	case 27:
+		// A lone Esc, or the start of a sequence like \033[A for an arrow
+		if ui.in.Buffered() == 0 {
+			return keyEscape, nil
+		}
+		if next, _, _ := ui.in.ReadRune(); next != '[' && next != 'O' {
+			return keyEscape, nil
+		}
+		seq := ""
+		for {
+			c, _, err := ui.in.ReadRune()
+			if err != nil {
+				return "", err
+			}
+			seq += string(c)
+			if c >= '@' && c <= '~' {
+				break
+			}
+		}
+		switch seq {
+		case "A":
+			return keyUp, nil
+		case "B":
+			return keyDown, nil
+		case "C":
+			return keyRight, nil
+		case "D":
+			return keyLeft, nil
+		case "5~":
+			return keyPageUp, nil
+		case "6~":
+			return keyPageDown, nil
+		}
+		return "", nil
+	}
+	return string(r), nil
+}
+
+// handle acts on a key press.
+func (ui *tui) handle(key string) {
+	ui.status = ""
+	if ui.modal != nil {
+		ui.handleModal(key)
+		return
+	}
+
+	switch strings.ToLower(key) {
+	case keyUp, "w", "1":
+		ui.move(lib.Directions[0])
+	case keyDown, "s", "2":
+		ui.move(lib.Directions[1])
+	case keyRight, "d", "3":
+		ui.move(lib.Directions[2])
+	case keyLeft, "a", "4":
+		ui.move(lib.Directions[3])
+	case keyPageUp, "[":
+		ui.scroll += ui.journalPage()
+	case keyPageDown, "]":
+		ui.scroll = max(ui.scroll-ui.journalPage(), 0)
+	case "i":
+		ui.inventory = !ui.inventory
+	case "f":
+		ui.game.FitMap()
+	case "c":
+		ui.game.CenterMap()
+	case "n":
+		ui.modal = &modal{
+			title:  "Write in your journal",
+			body:   fmt.Sprintf("Day %d. Type a line and press Enter.", ui.game.TurnCount),
+			asking: true,
+			cancel: true,
+			onText: func(text string) {
+				if ui.game.Write(text, "") == nil {
+					ui.status = fmt.Sprintf("Your words are added to Day %d.", ui.game.TurnCount)
+					ui.scroll = 0
+				}
+			},
+		}
+	case keyCtrlS:
+		if err := ui.game.SaveFile(lib.DefaultSaveFile); err != nil {
+			ui.status = fmt.Sprintf("Could not save your journey: %v", err)
+		} else {
+			ui.status = "Your journey has been saved to " + lib.DefaultSaveFile + "."
+		}
+	case "u":
+		if err := ui.game.Undo(); err != nil {
+			ui.status = err.Error()
+		} else {
+			ui.status = fmt.Sprintf("You retrace your steps to %s. It is Day %d again.", ui.game.CurrentTile().Theme, ui.game.TurnCount)
+		}
+	case "r":
+		if err := ui.game.Redo(); err != nil {
+			ui.status = err.Error()
+		} else {
+			ui.status = fmt.Sprintf("You set off once more and reach %s on Day %d.", ui.game.CurrentTile().Theme, ui.game.TurnCount)
+		}
+	case "?", "h":
+		ui.modal = &modal{title: "Keys", body: tuiHelp, cancel: true}
+	case "q", keyCtrlC:
+		ui.quit = true
+	}
+}
+
+const tuiHelp = `Arrow keys or WASD   walk north, west, south or east
+1-4                  the same, in the order north, south, east, west
+n                    write a line in your journal
+i                    switch between the journal and your items
+[ ] or PgUp PgDn     scroll the journal
+f                    zoom the map out to fit the whole world
+c                    centre the map on you again
+u r                  undo the last place you explored, or redo it
+Ctrl-S               save your journey to ` + lib.DefaultSaveFile + `
+q                    quit`
+
+// handleModal passes a key to the open box.
+func (ui *tui) handleModal(key string) {
+	m := ui.modal
+	switch {
+	case key == keyEscape:
+		if m.cancel {
+			ui.modal = nil
+		}
+	case key == keyCtrlC:
+		ui.quit = true
+	case m.asking && key == keyEnter:
+		ui.modal = nil
+		if m.onText != nil {
+			m.onText(string(m.input))
+		}
+	case m.asking && key == keyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case m.asking:
+		if r := []rune(key); len(r) == 1 && r[0] >= ' ' {
+			m.input = append(m.input, r[0])
+		}
+	case len(m.choices) == 0:
+		// A box with nothing to choose closes with any key
+		ui.modal = nil
+	case key == keyUp || key == "w" || key == "k":
+		m.selected = (m.selected + len(m.choices) - 1) % len(m.choices)
+	case key == keyDown || key == "s" || key == "j":
+		m.selected = (m.selected + 1) % len(m.choices)
+	case key == keyEnter:
+		ui.choose(m.selected)
+	case len(key) == 1 && key[0] >= '1' && int(key[0]-'1') < len(m.choices):
+		ui.choose(int(key[0] - '1'))
+	}
+}
+
+func (ui *tui) choose(choice int) {
+	m := ui.modal
+	ui.modal = nil
+	if m.onChoose != nil {
+		m.onChoose(choice)
+	}
+}
+
+// move walks one step: back onto a known place, or somewhere new once the
+// player has picked one of its three paths.
+func (ui *tui) move(dir lib.Direction) {
+	g := ui.game
+	x, y := g.CurrentX+dir.DX, g.CurrentY+dir.DY
+	if g.GetTile(x, y) != nil {
+		if err := g.Move(dir); err != nil {
+			ui.status = err.Error()
+			return
+		}
+		ui.scroll = 0
+		ui.status = g.LastEntry().Text
+		return
+	}
+
+	options := g.GenerateLocationOptions(x, y)
+	m := &modal{
+		title:  fmt.Sprintf("As you head %s, three paths reveal themselves", dir.Name),
+		cancel: true,
+		onChoose: func(choice int) {
+			ui.explore(dir, options[choice])
+		},
+	}
+	for _, opt := range options {
+		m.choices = append(m.choices, opt.Theme)
+		m.details = append(m.details, opt.Description)
+	}
+	ui.modal = m
+}
+
+func (ui *tui) explore(dir lib.Direction, option lib.LocationOption) {
+	item, err := ui.game.Explore(dir, option)
+	if err != nil {
+		ui.status = err.Error()
+		return
+	}
+	ui.scroll = 0
+	ui.status = "You arrive at " + option.Theme + "."
+	if item != nil {
+		ui.status += " You found something: " + item.Name + "!"
+	}
+
+	prompt := ui.game.DrawPrompt()
+	if prompt == nil {
+		ui.reflect()
+		return
+	}
+	ui.modal = &modal{
+		title:   "When you arrive...",
+		body:    prompt.Text,
+		choices: prompt.Choices,
+		onChoose: func(choice int) {
+			text, item, err := ui.game.ResolvePrompt(prompt, choice)
+			if err != nil {
+				ui.status = err.Error()
+				return
+			}
+			ui.status = text
+			if item != nil {
+				ui.status += " You found something: " + item.Name + "!"
+			}
+			ui.reflect()
+		},
+	}
+}
+
+// reflect offers the journaling prompt for a newly explored place.
+func (ui *tui) reflect() {
+	if !ui.reflections {
+		return
+	}
+	question := ui.game.Reflection()
+	if question == "" {
+		return
+	}
+	status := ui.status
+	ui.modal = &modal{
+		title:  "A moment to reflect",
+		body:   question + "\n\nType your thoughts and press Enter, or Esc to walk on.",
+		asking: true,
+		cancel: true,
+		onText: func(text string) {
+			if ui.game.Write(text, question) == nil {
+				status = "Your thoughts are tucked into your journal."
+			}
+			ui.status = status
+		},
+	}
+}
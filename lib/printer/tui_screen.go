@@ -0,0 +1,262 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The smallest terminal the full-screen layout fits in
+const (
+	tuiMinWidth  = 60
+	tuiMinHeight = 16
+)
+
+// draw redraws the whole screen. Every line is written at full width over the
+// last frame instead of clearing first, so the screen doesn't flicker.
+func (ui *tui) draw() {
+	width, height := TerminalSize()
+	var rows []string
+	if width < tuiMinWidth || height < tuiMinHeight {
+		rows = make([]string, height)
+		rows[height/2] = CenterText(fmt.Sprintf("Make the window at least %dx%d to keep wandering.", tuiMinWidth, tuiMinHeight), width)
+	} else {
+		rows = ui.layout(width, height)
+	}
+	for i := range rows {
+		rows[i] = Fit(rows[i], width)
+	}
+
+	ui.out.WriteString("\033[H" + strings.Join(rows, "\r\n"))
+	ui.out.Flush()
+}
+
+// layout puts the map on the left, the location above the journal on the
+// right and the status bar along the bottom, with any open box on top.
+func (ui *tui) layout(width, height int) []string {
+	g := ui.game
+	body := height - 1
+	rightWidth := max(34, width*2/5)
+	leftWidth := width - rightWidth
+
+	left := ui.mapPane(leftWidth, body)
+	placeHeight := body * 9 / 20
+	right := append(ui.placePane(rightWidth, placeHeight), ui.logPane(rightWidth, body-placeHeight)...)
+
+	rows := make([]string, 0, height)
+	for i := 0; i < body; i++ {
+		rows = append(rows, Fit(left[i], leftWidth)+right[i])
+	}
+
+	status := fmt.Sprintf(" Day %d · (%d, %d) · %d items · ", g.TurnCount, g.CurrentX, g.CurrentY, len(g.Inventory))
+	message := ui.status
+	if message == "" {
+		message = "arrows/WASD walk · n write · i items · [ ] scroll · f fit · c centre · ? keys · q quit"
+	}
+	rows = append(rows, "\033[7m"+Fit(status+message, width)+"\033[0m")
+
+	if ui.modal != nil {
+		ui.overlay(rows, ui.modalBox(min(width-4, 72)))
+	}
+	return rows
+}
+
+// mapPane draws the map as large as the pane allows, centred across it.
+func (ui *tui) mapPane(width, height int) []string {
+	g := ui.game
+	legend := []string{}
+	if biomes := ui.term.biomeLegend(g); biomes != "" {
+		legend = Wrap("Biomes: "+biomes, width-2)
+	}
+
+	// The box takes four rows and three columns around the cells
+	rows := max(height-4-len(legend), 3)
+	box := ui.term.mapBox(g, g.MapWindow(max((width-3)/3, 3), rows))
+	indent := strings.Repeat(" ", max((width-DisplayWidth(box[0]))/2, 0))
+
+	lines := make([]string, height)
+	for i, line := range append(box, legend...) {
+		if i < height {
+			lines[i] = indent + line
+		}
+	}
+	return lines
+}
+
+// placePane describes where the player stands and the ways out.
+func (ui *tui) placePane(width, height int) []string {
+	g := ui.game
+	tile := g.CurrentTile()
+	inner := width - 4
+	body := []string{}
+	if tile != nil {
+		biome := ""
+		if b := g.TileBiome(tile); b != nil {
+			biome = b.Name + " · "
+		}
+		body = append(body, ui.term.tint(g, tile, tile.Theme), biome+fmt.Sprintf("(%d, %d)", tile.X, tile.Y), "")
+		body = append(body, Wrap(tile.Description, inner)...)
+		for _, feature := range tile.Features {
+			body = append(body, Wrap("✨ Here you'll find "+feature+".", inner)...)
+		}
+		if tile.Item != nil {
+			body = append(body, Wrap("🎁 "+tile.Item.Name, inner)...)
+		}
+	}
+
+	body = append(body, "")
+	arrows := map[string]string{"North": "↑", "South": "↓", "East": "→", "West": "←"}
+	if ui.term.ASCII {
+		arrows = map[string]string{"North": "w", "South": "s", "East": "d", "West": "a"}
+	}
+	for _, exit := range g.GetExits() {
+		body = append(body, Fit(arrows[exit.Direction.Name]+" "+exit.Label(), inner))
+	}
+	return ui.panel("Here", body, width, height)
+}
+
+// logPane shows the end of the journal, or the inventory, scrolled back by
+// ui.scroll lines.
+func (ui *tui) logPane(width, height int) []string {
+	g := ui.game
+	inner := width - 4
+	lines := []string{}
+	title := "Journal"
+	if ui.inventory {
+		title = "Items"
+		for _, group := range g.InventoryGroups() {
+			lines = append(lines, group.Label)
+			for _, item := range group.Items {
+				lines = append(lines, wrapIndented(fmt.Sprintf("  %s, from %s on day %d", item.Name, item.FoundAt, item.FoundDay), inner)...)
+			}
+		}
+		if len(lines) == 0 {
+			lines = append(lines, "Your pack is empty for now.")
+		}
+	} else {
+		for _, entry := range g.JournalLog {
+			for _, line := range strings.Split(entry.String(), "\n") {
+				lines = append(lines, wrapIndented(line, inner)...)
+			}
+		}
+	}
+
+	// Keep the scroll inside the log, with the newest lines at the bottom
+	shown := height - 2
+	ui.scroll = min(ui.scroll, max(len(lines)-shown, 0))
+	end := len(lines) - ui.scroll
+	if ui.scroll > 0 {
+		title += fmt.Sprintf(" (%d more below)", ui.scroll)
+	}
+	return ui.panel(title, lines[max(end-shown, 0):end], width, height)
+}
+
+// journalPage is how far one press scrolls the journal.
+func (ui *tui) journalPage() int {
+	_, height := TerminalSize()
+	return max((height-1)/4, 1)
+}
+
+// wrapIndented wraps a line, repeating its leading spaces on every part.
+func wrapIndented(line string, width int) []string {
+	text := strings.TrimLeft(line, " ")
+	indent := strings.Repeat(" ", len(line)-len(text))
+	lines := Wrap(text, max(width-len(indent), 1))
+	for i := range lines {
+		lines[i] = indent + lines[i]
+	}
+	return lines
+}
+
+// panel draws body in a titled box exactly width by height, cutting off any
+// lines that don't fit.
+func (ui *tui) panel(title string, body []string, width, height int) []string {
+	box := []string{"┌", "─", "┐", "│", "└", "┘"}
+	if ui.term.ASCII {
+		box = []string{"+", "-", "+", "|", "+", "+"}
+	}
+
+	top := box[0] + box[1] + " " + title + " "
+	lines := []string{top + strings.Repeat(box[1], max(width-1-DisplayWidth(top), 0)) + box[2]}
+	for i := 0; i < height-2; i++ {
+		text := ""
+		if i < len(body) {
+			text = body[i]
+		}
+		lines = append(lines, box[3]+" "+Fit(text, width-4)+" "+box[3])
+	}
+	return append(lines, box[4]+strings.Repeat(box[1], width-2)+box[5])
+}
+
+// modalBox draws the open box, at most width columns wide.
+func (ui *tui) modalBox(width int) []string {
+	m := ui.modal
+	inner := width - 4
+	var body []string
+	if m.body != "" {
+		// Lines that fit keep their spacing, so tables stay lined up
+		for _, line := range strings.Split(m.body, "\n") {
+			if DisplayWidth(line) <= inner {
+				body = append(body, line)
+			} else {
+				body = append(body, Wrap(line, inner)...)
+			}
+		}
+	}
+
+	selected := "▶ "
+	if ui.term.ASCII {
+		selected = "> "
+	}
+	if len(m.choices) > 0 {
+		body = append(body, "")
+	}
+	for i, choice := range m.choices {
+		line := fmt.Sprintf("%d. %s", i+1, choice)
+		if i == m.selected {
+			line = "\033[7m" + Fit(selected+line, inner) + "\033[0m"
+		} else {
+			line = "  " + line
+		}
+		body = append(body, line)
+	}
+	if m.selected < len(m.details) {
+		body = append(body, "")
+		body = append(body, Wrap(m.details[m.selected], inner)...)
+	}
+
+	hint := "Any key to close"
+	switch {
+	case m.asking:
+		body = append(body, "", "> "+string(m.input)+"_")
+		// Show the end of long input
+		if over := DisplayWidth(body[len(body)-1]) - inner; over > 0 {
+			body[len(body)-1] = Slice(body[len(body)-1], over, over+inner)
+		}
+		hint = "Enter to finish"
+	case len(m.choices) > 0:
+		hint = "↑↓ and Enter, or a number"
+		if ui.term.ASCII {
+			hint = "up/down and Enter, or a number"
+		}
+	}
+	if m.cancel && (m.asking || len(m.choices) > 0) {
+		hint += " · Esc to go back"
+	}
+	body = append(body, "", hint)
+	return ui.panel(m.title, body, width, len(body)+2)
+}
+
+// overlay draws box over the middle of rows.
+func (ui *tui) overlay(rows, box []string) {
+	width := DisplayWidth(box[0])
+	screen := DisplayWidth(rows[0])
+	top := max((len(rows)-len(box))/2, 0)
+	left := max((screen-width)/2, 0)
+	for i, line := range box {
+		if top+i >= len(rows)-1 {
+			break
+		}
+		row := rows[top+i]
+		rows[top+i] = Slice(row, 0, left) + line + Slice(row, left+width, screen)
+	}
+}
@@ -0,0 +1,65 @@
+package lib
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+)
+
+// countingSource wraps a seeded rand.Source and counts how many values have
+// been drawn from it, so the generator state can be saved as (seed, draws)
+// and restored later by replaying the same number of draws.
+type countingSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{
+		src:  rand.NewSource(seed).(rand.Source64),
+		seed: seed,
+	}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
+
+// skip advances the source by n draws without handing the values out.
+func (s *countingSource) skip(n uint64) {
+	for i := uint64(0); i < n; i++ {
+		s.Uint64()
+	}
+}
+
+// tileRand returns a generator derived from the world seed, the tile
+// coordinates and a salt naming what is being generated, so the same tile
+// always produces the same content however the player got there.
+func (g *Game) tileRand(x, y int, salt string) *rand.Rand {
+	return rand.New(rand.NewSource(int64(g.tileHash(x, y, salt))))
+}
+
+// tileHash mixes the world seed, coordinates and salt into a single value.
+func (g *Game) tileHash(x, y int, salt string) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, v := range []int64{g.source.seed, int64(x), int64(y)} {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		h.Write(buf[:])
+	}
+	h.Write([]byte(salt))
+	return h.Sum64()
+}
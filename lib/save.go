@@ -0,0 +1,287 @@
+package lib
+
+import (
+	"GentleWanderings/lib/content"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SaveVersion is the schema version written by Save. Bump it whenever the
+// save format changes and add a migration from the previous version.
+const SaveVersion = 4
+
+// DefaultSaveFile is used when no save file name is given.
+const DefaultSaveFile = "journey.json"
+
+// saveFile is the on-disk representation of a journey.
+type saveFile struct {
+	Version    int              `json:"version"`
+	Map        map[string]*Tile `json:"map"`
+	CurrentX   int              `json:"current_x"`
+	CurrentY   int              `json:"current_y"`
+	TurnCount  int              `json:"turn_count"`
+	JournalLog []JournalEntry   `json:"journal_log"`
+	Inventory  []*Item          `json:"inventory"`
+	RNG        rngState         `json:"rng"`
+	Log        *ActionLog       `json:"log,omitempty"`    // missing from journeys begun before version 3
+	Prompt     *savedPrompt     `json:"prompt,omitempty"` // the card waiting for an answer, if any
+	SavedAt    time.Time        `json:"saved_at"`
+}
+
+// rngState captures the generator as its seed plus the number of values drawn.
+type rngState struct {
+	Seed  int64  `json:"seed"`
+	Draws uint64 `json:"draws"`
+}
+
+// savedPrompt is a card drawn and not yet answered. The card itself is kept,
+// as the content it came from may have changed by the time it is answered.
+type savedPrompt struct {
+	Text    string          `json:"text"`
+	Choices []string        `json:"choices"`
+	Card    *content.Prompt `json:"card"`
+}
+
+// migrations upgrade a raw save document from the keyed version to the next.
+var migrations = map[int]func(doc map[string]json.RawMessage) error{
+	1: migrateJournalEntries,
+	2: migrateActionLog,
+	3: migratePendingPrompt,
+}
+
+// migratePendingPrompt leaves a version 3 save without a card to answer:
+// any card drawn when it was saved was lost.
+func migratePendingPrompt(doc map[string]json.RawMessage) error {
+	delete(doc, "prompt")
+	return nil
+}
+
+// migrateActionLog leaves a version 2 save without an action log: what was
+// done before it can't be recovered, so the journey can't be replayed.
+func migrateActionLog(doc map[string]json.RawMessage) error {
+	delete(doc, "log")
+	return nil
+}
+
+// migrateJournalEntries turns the version 1 journal of "Day N: ..." and
+// "  → Found: ..." strings into structured entries. Arrivals are matched to
+// tiles by their discovery text, returns by the last place they name, and
+// found items are placed on the tile of the entry before them. Where several
+// tiles fit, the one nearest the entry before is taken, and each tile is
+// arrived at only once.
+func migrateJournalEntries(doc map[string]json.RawMessage) error {
+	var lines []string
+	if err := json.Unmarshal(doc["journal_log"], &lines); err != nil {
+		return fmt.Errorf("reading journal: %w", err)
+	}
+	var tileMap map[string]*Tile
+	if err := json.Unmarshal(doc["map"], &tileMap); err != nil {
+		return fmt.Errorf("reading map: %w", err)
+	}
+	tiles := make([]*Tile, 0, len(tileMap))
+	for _, tile := range tileMap {
+		tiles = append(tiles, tile)
+	}
+	sort.Slice(tiles, func(i, j int) bool {
+		if tiles[i].X != tiles[j].X {
+			return tiles[i].X < tiles[j].X
+		}
+		return tiles[i].Y < tiles[j].Y
+	})
+
+	entries := []JournalEntry{}
+	arrived := map[*Tile]bool{}
+	last := JournalEntry{Day: 1}
+	for _, line := range lines {
+		entry := JournalEntry{Day: last.Day, X: last.X, Y: last.Y, Author: AuthorEngine}
+		nearer := func(tile, than *Tile) bool {
+			return than == nil || steps(tile, last) < steps(than, last)
+		}
+
+		if name, ok := strings.CutPrefix(line, "  → Found: "); ok {
+			entry.Kind = EntryItem
+			entry.Text = name
+		} else {
+			var day int
+			var text string
+			if dayText, rest, ok := strings.Cut(line, ": "); ok {
+				if _, err := fmt.Sscanf(dayText, "Day %d", &day); err == nil {
+					entry.Day = day
+					text = rest
+				}
+			}
+			if text == "" {
+				text = line
+			}
+			entry.Text = text
+			entry.Kind = EntryReturn
+
+			var arrival, named *Tile
+			best := -1
+			for _, tile := range tiles {
+				if tile.Discovery == text && !arrived[tile] && nearer(tile, arrival) {
+					arrival = tile
+				}
+				// Returns and travel name where they end up last in the text
+				i := strings.LastIndex(strings.ToLower(text), strings.ToLower(tile.Theme))
+				if i >= 0 && (i > best || i == best && nearer(tile, named)) {
+					best = i
+					named = tile
+				}
+			}
+			switch {
+			case arrival != nil:
+				entry.Kind = EntryArrival
+				entry.X, entry.Y = arrival.X, arrival.Y
+				arrived[arrival] = true
+			case named != nil:
+				entry.X, entry.Y = named.X, named.Y
+			}
+		}
+
+		entries = append(entries, entry)
+		last = entry
+	}
+
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	doc["journal_log"] = raw
+	return nil
+}
+
+// steps is how far a tile is from where a journal entry was written.
+func steps(tile *Tile, entry JournalEntry) int {
+	dx, dy := tile.X-entry.X, tile.Y-entry.Y
+	return max(dx, -dx) + max(dy, -dy)
+}
+
+// Save writes the game state as versioned JSON.
+func (g *Game) Save(w io.Writer) error {
+	data := saveFile{
+		Version:    SaveVersion,
+		Map:        g.Map,
+		CurrentX:   g.CurrentX,
+		CurrentY:   g.CurrentY,
+		TurnCount:  g.TurnCount,
+		JournalLog: g.JournalLog,
+		Inventory:  g.Inventory,
+		RNG: rngState{
+			Seed:  g.source.seed,
+			Draws: g.source.draws,
+		},
+		Log:     g.log,
+		SavedAt: time.Now(),
+	}
+	if g.prompt != nil {
+		data.Prompt = &savedPrompt{Text: g.prompt.Text, Choices: g.prompt.Choices, Card: g.prompt.card}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+// LoadGame reads a game saved with Save, upgrading older save versions.
+func LoadGame(r io.Reader) (*Game, error) {
+	var doc map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("reading save: %w", err)
+	}
+
+	var version int
+	if raw, ok := doc["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("reading save version: %w", err)
+		}
+	}
+	if version < 1 {
+		return nil, errors.New("save has no version")
+	}
+	if version > SaveVersion {
+		return nil, fmt.Errorf("save version %d is newer than supported version %d", version, SaveVersion)
+	}
+
+	for v := version; v < SaveVersion; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("no migration from save version %d", v)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, fmt.Errorf("migrating save from version %d: %w", v, err)
+		}
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var data saveFile
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("reading save: %w", err)
+	}
+
+	g := &Game{
+		Map:        data.Map,
+		CurrentX:   data.CurrentX,
+		CurrentY:   data.CurrentY,
+		TurnCount:  data.TurnCount,
+		JournalLog: data.JournalLog,
+		Inventory:  data.Inventory,
+		source:     newCountingSource(data.RNG.Seed),
+		content:    content.Default(),
+		log:        data.Log,
+		history:    history{depth: DefaultHistory},
+	}
+	g.source.skip(data.RNG.Draws)
+	g.rand = rand.New(g.source)
+	if p := data.Prompt; p != nil && p.Card != nil {
+		g.prompt = &Prompt{Text: p.Text, Choices: p.Choices, card: p.Card}
+	}
+
+	if g.Map == nil {
+		g.Map = make(map[string]*Tile)
+	}
+	if g.JournalLog == nil {
+		g.JournalLog = []JournalEntry{}
+	}
+	if g.Inventory == nil {
+		g.Inventory = []*Item{}
+	}
+	if g.GetTile(g.CurrentX, g.CurrentY) == nil {
+		return nil, fmt.Errorf("save places you at (%d, %d) but that tile is missing", g.CurrentX, g.CurrentY)
+	}
+
+	return g, nil
+}
+
+// SaveFile writes the game state to the file at path, replacing it if present.
+func (g *Game) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := g.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadGameFile reads a game from the save file at path.
+func LoadGameFile(path string) (*Game, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadGame(f)
+}
@@ -0,0 +1,156 @@
+package lib
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// wander explores along dirs, taking the first path offered each time.
+func wander(t *testing.T, g *Game, dirs ...string) {
+	t.Helper()
+	for _, name := range dirs {
+		dir, err := DirectionNamed(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		options := g.GenerateLocationOptions(g.CurrentX+dir.DX, g.CurrentY+dir.DY)
+		if _, err := g.Explore(dir, options[0]); err != nil {
+			t.Fatalf("exploring %s: %v", name, err)
+		}
+	}
+}
+
+// roundTrip saves g and loads it again.
+func roundTrip(t *testing.T, g *Game) *Game {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := g.Save(&buf); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+	loaded, err := LoadGame(&buf)
+	if err != nil {
+		t.Fatalf("loading: %v", err)
+	}
+	return loaded
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	g := NewGameWithSeed(42)
+	wander(t, g, "North", "East", "East", "North")
+	if err := g.Move(Directions[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Write("A quiet day.", "What did you hear?"); err != nil {
+		t.Fatal(err)
+	}
+	g.DrawPrompt()
+
+	loaded := roundTrip(t, g)
+	if !reflect.DeepEqual(loaded.Map, g.Map) {
+		t.Errorf("map changed:\n got %v\nwant %v", loaded.Map, g.Map)
+	}
+	if !reflect.DeepEqual(loaded.Inventory, g.Inventory) {
+		t.Errorf("inventory changed:\n got %v\nwant %v", loaded.Inventory, g.Inventory)
+	}
+	if !reflect.DeepEqual(loaded.JournalLog, g.JournalLog) {
+		t.Errorf("journal changed:\n got %v\nwant %v", loaded.JournalLog, g.JournalLog)
+	}
+	if loaded.CurrentX != g.CurrentX || loaded.CurrentY != g.CurrentY || loaded.TurnCount != g.TurnCount {
+		t.Errorf("at (%d, %d) on day %d, want (%d, %d) on day %d",
+			loaded.CurrentX, loaded.CurrentY, loaded.TurnCount, g.CurrentX, g.CurrentY, g.TurnCount)
+	}
+	if loaded.source.seed != g.source.seed || loaded.source.draws != g.source.draws {
+		t.Errorf("generator at seed %d after %d draws, want seed %d after %d",
+			loaded.source.seed, loaded.source.draws, g.source.seed, g.source.draws)
+	}
+	if !reflect.DeepEqual(loaded.Log(), g.Log()) {
+		t.Errorf("action log changed:\n got %v\nwant %v", loaded.Log(), g.Log())
+	}
+
+	// Both carry on drawing the same numbers
+	for i := 0; i < 5; i++ {
+		if got, want := loaded.rand.Int63(), g.rand.Int63(); got != want {
+			t.Fatalf("draw %d after loading is %d, want %d", i+1, got, want)
+		}
+	}
+}
+
+func TestLoadOldSaves(t *testing.T) {
+	for _, path := range []string{"testdata/save_v1.json", "testdata/save_v2.json"} {
+		t.Run(path, func(t *testing.T) {
+			g, err := LoadGameFile(path)
+			if err != nil {
+				t.Fatalf("loading: %v", err)
+			}
+			if len(g.Map) != 5 {
+				t.Errorf("%d tiles, want 5", len(g.Map))
+			}
+			if g.CurrentX != 2 || g.CurrentY != 1 || g.TurnCount != 6 {
+				t.Errorf("at (%d, %d) on day %d, want (2, 1) on day 6", g.CurrentX, g.CurrentY, g.TurnCount)
+			}
+			if len(g.Inventory) != 1 || g.Inventory[0].Name != "Moss Sample" {
+				t.Errorf("inventory is %v, want the Moss Sample", g.Inventory)
+			}
+			if g.source.seed != 42 || g.source.draws != 12 {
+				t.Errorf("generator at seed %d after %d draws, want seed 42 after 12", g.source.seed, g.source.draws)
+			}
+			if g.Log() != nil {
+				t.Error("a journey saved before it was logged can be replayed")
+			}
+			if first := g.JournalLog[0]; first.Kind != EntryArrival || first.Day != 1 {
+				t.Errorf("journal begins with %+v, want the day 1 arrival", first)
+			}
+
+			// And it can be saved again at the current version
+			again := roundTrip(t, g)
+			if !reflect.DeepEqual(again.JournalLog, g.JournalLog) {
+				t.Errorf("journal changed saving again:\n got %v\nwant %v", again.JournalLog, g.JournalLog)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsUnknownVersions(t *testing.T) {
+	for _, save := range []string{`{"map": {}}`, `{"version": 999, "map": {}}`} {
+		if _, err := LoadGame(strings.NewReader(save)); err == nil {
+			t.Errorf("loaded %s", save)
+		}
+	}
+}
+
+func TestMigrateJournalEntries(t *testing.T) {
+	g, err := LoadGameFile("testdata/save_v1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two mushroom circles share their discovery text, so each arrival must
+	// go to a different one, and the return to the nearer
+	want := []JournalEntry{
+		{Day: 1, X: 0, Y: 0, Kind: EntryArrival},
+		{Day: 2, X: 0, Y: 1, Kind: EntryArrival},
+		{Day: 2, X: 0, Y: 1, Kind: EntryItem, Text: "Moss Sample"},
+		{Day: 3, X: 1, Y: 1, Kind: EntryArrival},
+		{Day: 4, X: 2, Y: 1, Kind: EntryArrival},
+		{Day: 5, X: 2, Y: 2, Kind: EntryArrival},
+		{Day: 6, X: 2, Y: 1, Kind: EntryReturn, Text: "Mushroom circle welcomes you back."},
+	}
+	if len(g.JournalLog) != len(want) {
+		t.Fatalf("%d journal entries, want %d: %v", len(g.JournalLog), len(want), g.JournalLog)
+	}
+	for i, w := range want {
+		got := g.JournalLog[i]
+		if got.Day != w.Day || got.X != w.X || got.Y != w.Y || got.Kind != w.Kind || got.Author != AuthorEngine {
+			t.Errorf("entry %d is %s on day %d at (%d, %d) by %s, want %s on day %d at (%d, %d)",
+				i+1, got.Kind, got.Day, got.X, got.Y, got.Author, w.Kind, w.Day, w.X, w.Y)
+		}
+		if w.Text != "" && got.Text != w.Text {
+			t.Errorf("entry %d says %q, want %q", i+1, got.Text, w.Text)
+		}
+		if w.Kind == EntryArrival && got.Text != g.GetTile(got.X, got.Y).Discovery {
+			t.Errorf("entry %d says %q, not the discovery at (%d, %d)", i+1, got.Text, got.X, got.Y)
+		}
+	}
+}
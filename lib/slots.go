@@ -0,0 +1,220 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SlotExt is the file extension used for journeys stored in a save slot.
+const SlotExt = ".journey"
+
+// SlotInfo summarises a saved journey for the slot browser.
+type SlotInfo struct {
+	Name       string
+	Day        int
+	Tiles      int
+	LastPlayed time.Time
+	Damaged    error // why the slot can't be read, if it can't
+}
+
+// Slots manages named save slots stored as files in a directory.
+type Slots struct {
+	Dir string
+}
+
+// NewSlots returns a slot manager for the given save directory.
+func NewSlots(dir string) *Slots {
+	return &Slots{Dir: dir}
+}
+
+func (s *Slots) path(name string) string {
+	return filepath.Join(s.Dir, name+SlotExt)
+}
+
+// validSlotName rejects names that can't be stored as a single file.
+func validSlotName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return errors.New("slot name cannot be empty")
+	case strings.ContainsAny(name, `/\:`):
+		return fmt.Errorf("slot name %q cannot contain / \\ or :", name)
+	case strings.HasPrefix(name, "."):
+		return fmt.Errorf("slot name %q cannot start with a dot", name)
+	}
+	return nil
+}
+
+// List returns every saved slot, most recently played first. A slot that
+// can't be read is still listed, with Damaged saying why, so it can be
+// deleted.
+func (s *Slots) List() ([]SlotInfo, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []SlotInfo{}, nil
+		}
+		return nil, err
+	}
+
+	slots := []SlotInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != SlotExt || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), SlotExt)
+		info, err := s.info(name)
+		if err != nil {
+			info = SlotInfo{Name: name, Damaged: err}
+			if stat, err := entry.Info(); err == nil {
+				info.LastPlayed = stat.ModTime()
+			}
+		}
+		slots = append(slots, info)
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		if !slots[i].LastPlayed.Equal(slots[j].LastPlayed) {
+			return slots[i].LastPlayed.After(slots[j].LastPlayed)
+		}
+		return slots[i].Name < slots[j].Name
+	})
+	return slots, nil
+}
+
+// info reads just enough of a slot file to describe it.
+func (s *Slots) info(name string) (SlotInfo, error) {
+	f, err := os.Open(s.path(name))
+	if err != nil {
+		return SlotInfo{}, err
+	}
+	defer f.Close()
+
+	var header struct {
+		Map       map[string]json.RawMessage `json:"map"`
+		TurnCount int                        `json:"turn_count"`
+		SavedAt   time.Time                  `json:"saved_at"`
+	}
+	if err := json.NewDecoder(f).Decode(&header); err != nil {
+		return SlotInfo{}, err
+	}
+
+	lastPlayed := header.SavedAt
+	if lastPlayed.IsZero() {
+		if stat, err := f.Stat(); err == nil {
+			lastPlayed = stat.ModTime()
+		}
+	}
+
+	return SlotInfo{
+		Name:       name,
+		Day:        header.TurnCount,
+		Tiles:      len(header.Map),
+		LastPlayed: lastPlayed,
+	}, nil
+}
+
+// Exists reports whether a slot with the given name has been saved.
+func (s *Slots) Exists(name string) bool {
+	if validSlotName(name) != nil {
+		return false
+	}
+	_, err := os.Stat(s.path(name))
+	return err == nil
+}
+
+// Save stores the game in the named slot, overwriting any previous save.
+// The save is written beside the slot first, so a failed write leaves the
+// old one as it was.
+func (s *Slots) Save(name string, g *Game) error {
+	if err := validSlotName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.Dir, "."+name+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	if err := g.Save(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), s.path(name)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// Load restores the game stored in the named slot.
+func (s *Slots) Load(name string) (*Game, error) {
+	if err := validSlotName(name); err != nil {
+		return nil, err
+	}
+	return LoadGameFile(s.path(name))
+}
+
+// Rename moves a slot to a new name.
+func (s *Slots) Rename(name, newName string) error {
+	if err := validSlotName(name); err != nil {
+		return err
+	}
+	if err := validSlotName(newName); err != nil {
+		return err
+	}
+	if s.Exists(newName) {
+		return fmt.Errorf("a slot named %q already exists", newName)
+	}
+	return os.Rename(s.path(name), s.path(newName))
+}
+
+// Duplicate copies a slot under a new name.
+func (s *Slots) Duplicate(name, copyName string) error {
+	if err := validSlotName(name); err != nil {
+		return err
+	}
+	if err := validSlotName(copyName); err != nil {
+		return err
+	}
+	if s.Exists(copyName) {
+		return fmt.Errorf("a slot named %q already exists", copyName)
+	}
+
+	src, err := os.Open(s.path(name))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(s.path(copyName))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// Delete removes a slot.
+func (s *Slots) Delete(name string) error {
+	if err := validSlotName(name); err != nil {
+		return err
+	}
+	return os.Remove(s.path(name))
+}
@@ -0,0 +1,83 @@
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSlotsListDamaged(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	if err := slots.Save("good", NewGameWithSeed(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(slots.Dir, "broken"+SlotExt), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := slots.List()
+	if err != nil {
+		t.Fatalf("listing: %v", err)
+	}
+	damaged := map[string]bool{}
+	for _, slot := range list {
+		damaged[slot.Name] = slot.Damaged != nil
+	}
+	if len(list) != 2 || damaged["good"] || !damaged["broken"] {
+		t.Fatalf("listed %+v, want good and a damaged broken", list)
+	}
+
+	// A damaged slot can still be deleted
+	if err := slots.Delete("broken"); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := slots.List(); len(list) != 1 {
+		t.Errorf("%d slots left, want 1", len(list))
+	}
+}
+
+func TestSlotsRejectBadNames(t *testing.T) {
+	slots := NewSlots(filepath.Join(t.TempDir(), "saves"))
+	if err := slots.Save("good", NewGameWithSeed(1)); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"../x", `..\x`, ".hidden", "", "a/b"} {
+		if _, err := slots.Load(name); err == nil {
+			t.Errorf("loaded %q", name)
+		}
+		if err := slots.Delete(name); err == nil {
+			t.Errorf("deleted %q", name)
+		}
+		if err := slots.Rename(name, "other"); err == nil {
+			t.Errorf("renamed %q", name)
+		}
+		if err := slots.Duplicate(name, "other"); err == nil {
+			t.Errorf("copied %q", name)
+		}
+		if err := slots.Save(name, NewGameWithSeed(1)); err == nil {
+			t.Errorf("saved %q", name)
+		}
+	}
+}
+
+func TestSlotsSaveOverwrites(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	for _, seed := range []int64{1, 2} {
+		if err := slots.Save("journey", NewGameWithSeed(seed)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loaded, err := slots.Load("journey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Seed() != 2 {
+		t.Errorf("slot holds seed %d, want 2", loaded.Seed())
+	}
+
+	// Nothing is left over from writing beside the slot
+	entries, _ := os.ReadDir(slots.Dir)
+	if len(entries) != 1 {
+		t.Errorf("%d files in the save directory, want just the slot", len(entries))
+	}
+}
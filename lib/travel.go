@@ -0,0 +1,129 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindPath returns the shortest route across explored tiles from the current
+// tile to (x, y), including both ends, or nil if no such route exists.
+func (g *Game) FindPath(x, y int) []*Tile {
+	start := g.GetTile(g.CurrentX, g.CurrentY)
+	goal := g.GetTile(x, y)
+	if start == nil || goal == nil {
+		return nil
+	}
+
+	// Breadth-first search, remembering how we reached each tile
+	cameFrom := map[*Tile]*Tile{start: nil}
+	queue := []*Tile{start}
+	for len(queue) > 0 {
+		tile := queue[0]
+		queue = queue[1:]
+
+		if tile == goal {
+			break
+		}
+
+		for _, dir := range Directions {
+			next := g.GetTile(tile.X+dir.DX, tile.Y+dir.DY)
+			if next == nil {
+				continue
+			}
+			if _, seen := cameFrom[next]; seen {
+				continue
+			}
+			cameFrom[next] = tile
+			queue = append(queue, next)
+		}
+	}
+
+	if _, reached := cameFrom[goal]; !reached {
+		return nil
+	}
+
+	path := []*Tile{}
+	for tile := goal; tile != nil; tile = cameFrom[tile] {
+		path = append([]*Tile{tile}, path...)
+	}
+	return path
+}
+
+// FindTileByTheme returns the explored tile with the given theme (ignoring
+// case) that is the fewest steps away, or nil if there is none.
+func (g *Game) FindTileByTheme(theme string) *Tile {
+	var best *Tile
+	bestSteps := 0
+	for _, tile := range g.Map {
+		if !strings.EqualFold(tile.Theme, theme) {
+			continue
+		}
+		path := g.FindPath(tile.X, tile.Y)
+		if path == nil {
+			continue
+		}
+		if best == nil || len(path) < bestSteps {
+			best = tile
+			bestSteps = len(path)
+		}
+	}
+	return best
+}
+
+// Travel walks the given route, as returned by FindPath, in one go. Each step
+// costs a day and the whole trip is written as a single journal entry.
+func (g *Game) Travel(path []*Tile) error {
+	route := [][2]int{}
+	for _, tile := range path {
+		route = append(route, [2]int{tile.X, tile.Y})
+	}
+	_, err := g.Apply(Action{Kind: ActionTravel, Route: route})
+	return err
+}
+
+func (g *Game) travel(path []*Tile) error {
+	if len(path) < 2 {
+		return fmt.Errorf("you are already here")
+	}
+	if path[0].X != g.CurrentX || path[0].Y != g.CurrentY {
+		return fmt.Errorf("the route must start where you are standing")
+	}
+	for i := 1; i < len(path); i++ {
+		prev, tile := path[i-1], path[i]
+		dx, dy := tile.X-prev.X, tile.Y-prev.Y
+		if dx*dx+dy*dy != 1 {
+			return fmt.Errorf("the route jumps from %s to %s", prev.Theme, tile.Theme)
+		}
+		if g.GetTile(tile.X, tile.Y) == nil {
+			return fmt.Errorf("the route passes through unexplored land at (%d, %d)", tile.X, tile.Y)
+		}
+	}
+
+	from := path[0]
+	to := path[len(path)-1]
+	days := len(path) - 1
+
+	g.CurrentX = to.X
+	g.CurrentY = to.Y
+	g.TurnCount += days
+
+	var text string
+	switch days {
+	case 1:
+		text = fmt.Sprintf("You travel from %s to %s.",
+			strings.ToLower(from.Theme), strings.ToLower(to.Theme))
+	default:
+		text = fmt.Sprintf("After %d days retracing familiar paths from %s, you arrive at %s.",
+			days, strings.ToLower(from.Theme), strings.ToLower(to.Theme))
+	}
+	g.logEntry(EntryTravel, text)
+
+	// The maps draw the way it went
+	route := [][2]int{}
+	for _, tile := range path {
+		route = append(route, [2]int{tile.X, tile.Y})
+	}
+	g.JournalLog[len(g.JournalLog)-1].Route = route
+
+	return nil
+}
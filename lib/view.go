@@ -0,0 +1,16 @@
+package lib
+
+// View shows the game to the player. The engine only answers questions about
+// the journey; a View decides how to draw the answers. The terminal front end
+// in lib/printer is one, and the GUI or a test can provide others.
+type View interface {
+	ShowMap(g *Game)                      // the map around the player
+	ShowRoute(g *Game, route []*Tile)     // the map with a planned route highlighted
+	ShowDetailedMap(g *Game)              // every location by name
+	ShowInventory(g *Game)                // the items collected so far
+	ShowJournal(g *Game)                  // the journey log
+	ShowCurrentLocation(g *Game)          // where the player stands
+	ShowStatistics(g *Game)               // how far the journey has come
+	ShowSlots(g *Game, slots *Slots)      // the named saves, to load, rename or delete
+	ShowMenu(run func(line string) error) // the commands to pick from, running the one chosen
+}
@@ -0,0 +1,101 @@
+package lib
+
+// mapView is where the map is scrolled to. The zero value follows the player
+// at full zoom.
+type mapView struct {
+	x, y   int     // the tile at the centre, once panned
+	panned bool    // false keeps the player in the centre
+	fit    bool    // zoom out until everything fits
+	route  []*Tile // when fitting, fit just these tiles
+
+	// The last window drawn, so panning knows where it starts and how far to go
+	shownX, shownY int
+	spanX, spanY   int
+}
+
+// PanMap scrolls the map half a screen in the given direction.
+func (g *Game) PanMap(dir Direction) {
+	v := &g.view
+	if !v.panned {
+		v.x, v.y = g.CurrentX, g.CurrentY
+		if v.spanX > 0 {
+			v.x, v.y = v.shownX, v.shownY
+		}
+	}
+	v.panned = true
+	v.fit = false
+
+	v.x += dir.DX * max(v.spanX/2, 1)
+	v.y += dir.DY * max(v.spanY/2, 1)
+
+	minX, maxX, minY, maxY := g.Bounds()
+	v.x = min(max(v.x, minX), maxX)
+	v.y = min(max(v.y, minY), maxY)
+}
+
+// FitMap zooms the map out until the whole world fits on screen.
+func (g *Game) FitMap() {
+	g.view = mapView{fit: true}
+}
+
+// CenterMap brings the map back to the player at full zoom.
+func (g *Game) CenterMap() {
+	g.view = mapView{}
+}
+
+// window works out which tiles are on screen in a map cols cells wide and
+// rows cells high.
+func (v *mapView) window(g *Game, cols, rows int) *MapGrid {
+	minX, maxX, minY, maxY := g.Bounds()
+	minX, maxX, minY, maxY = minX-1, maxX+1, minY-1, maxY+1
+
+	cx, cy := g.CurrentX, g.CurrentY
+	if v.panned {
+		cx, cy = v.x, v.y
+	}
+
+	scale := 1
+	if v.fit {
+		fitMinX, fitMaxX, fitMinY, fitMaxY := minX, maxX, minY, maxY
+		if len(v.route) > 0 {
+			fitMinX, fitMaxX, fitMinY, fitMaxY = routeBounds(v.route)
+		}
+		cx, cy = floorDiv(fitMinX+fitMaxX, 2), floorDiv(fitMinY+fitMaxY, 2)
+		scale = max(ceilDiv(fitMaxX-fitMinX+1, cols), ceilDiv(fitMaxY-fitMinY+1, rows), 1)
+	}
+
+	left, spanX := windowSpan(minX, maxX, cx, cols, scale)
+	bottom, spanY := windowSpan(minY, maxY, cy, rows, scale)
+
+	v.shownX, v.shownY = left+spanX/2, bottom+spanY/2
+	v.spanX, v.spanY = spanX, spanY
+	return g.mapWindow(left, left+spanX-1, bottom, bottom+spanY-1, scale, v.route)
+}
+
+// windowSpan fits cells of scale tiles between lo and hi along one axis,
+// centred on c where the world is too big to show whole. It returns the first
+// tile on screen and how many tiles are shown.
+func windowSpan(lo, hi, c, cells, scale int) (start, span int) {
+	need := ceilDiv(hi-lo+1, scale) * scale
+	if need <= cells*scale {
+		return lo, need
+	}
+	span = cells * scale
+	start = c - span/2
+	return min(max(start, lo), hi-span+1), span
+}
+
+// routeBounds is the box around a route with a one-tile margin.
+func routeBounds(route []*Tile) (minX, maxX, minY, maxY int) {
+	minX, maxX, minY, maxY = route[0].X, route[0].X, route[0].Y, route[0].Y
+	for _, tile := range route {
+		minX, maxX = min(minX, tile.X), max(maxX, tile.X)
+		minY, maxY = min(minY, tile.Y), max(maxY, tile.Y)
+	}
+	return minX - 1, maxX + 1, minY - 1, maxY + 1
+}
+
+// ceilDiv divides positive numbers rounding up.
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
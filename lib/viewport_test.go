@@ -0,0 +1,91 @@
+package lib
+
+import (
+	"slices"
+	"testing"
+)
+
+// longWalk heads ten tiles east, making a world far wider than it is tall.
+func longWalk(t *testing.T) *Game {
+	t.Helper()
+	g := NewGameWithSeed(42)
+	wander(t, g, slices.Repeat([]string{"East"}, 10)...)
+	return g
+}
+
+func TestMapWindowClipsAtTheEdge(t *testing.T) {
+	g := longWalk(t)
+
+	// The player is at the east end, so the window stops at the frontier
+	// rather than centring on them
+	grid := g.MapWindow(5, 3)
+	if grid.MinX != 7 || grid.MaxX != 11 || grid.MinY != -1 || grid.MaxY != 1 || grid.Scale != 1 {
+		t.Fatalf("window is x %d..%d, y %d..%d at 1:%d, want x 7..11, y -1..1 at 1:1",
+			grid.MinX, grid.MaxX, grid.MinY, grid.MaxY, grid.Scale)
+	}
+	if len(grid.Cells) != 3 || len(grid.Cells[0]) != 5 {
+		t.Fatalf("%d by %d cells, want 5 by 3", len(grid.Cells[0]), len(grid.Cells))
+	}
+	if grid.Cells[1][3] != CellPlayer {
+		t.Errorf("player is not at (10, 0): %v", grid.Cells[1])
+	}
+	if want := []bool{false, true, false}; !slices.Equal(grid.West, want) || slices.Contains(grid.East, true) {
+		t.Errorf("arrows west %v and east %v, want west %v only", grid.West, grid.East, want)
+	}
+	if slices.Contains(grid.North, true) || slices.Contains(grid.South, true) {
+		t.Errorf("arrows north %v and south %v on a map that fits its height", grid.North, grid.South)
+	}
+}
+
+func TestMapWindowFits(t *testing.T) {
+	g := longWalk(t)
+	g.FitMap()
+
+	// 13 tiles across, frontier included, take 3 to a cell to fit in 5
+	grid := g.MapWindow(5, 3)
+	if grid.Scale != 3 {
+		t.Fatalf("fit at 1:%d, want 1:3", grid.Scale)
+	}
+	for _, tile := range g.Map {
+		if tile.X < grid.MinX || tile.X > grid.MaxX || tile.Y < grid.MinY || tile.Y > grid.MaxY {
+			t.Errorf("(%d, %d) is off a fitted map of x %d..%d, y %d..%d", tile.X, tile.Y, grid.MinX, grid.MaxX, grid.MinY, grid.MaxY)
+		}
+	}
+	if len(grid.Cells) > 3 || len(grid.Cells[0]) > 5 {
+		t.Errorf("%d by %d cells, want no more than 5 by 3", len(grid.Cells[0]), len(grid.Cells))
+	}
+	for _, edge := range [][]bool{grid.North, grid.South, grid.West, grid.East} {
+		if slices.Contains(edge, true) {
+			t.Fatal("a fitted map points off screen")
+		}
+	}
+
+	// A world that already fits stays at full zoom
+	if grid := g.MapWindow(20, 5); grid.Scale != 1 {
+		t.Errorf("fit at 1:%d in a big window, want 1:1", grid.Scale)
+	}
+}
+
+func TestPanMap(t *testing.T) {
+	g := longWalk(t)
+	g.MapWindow(5, 3)
+
+	// Half a screen at a time, stopping at the last explored tile
+	west := Directions[3]
+	wantLeft := []int{5, 3, 1, -1, -1, -1}
+	for i, want := range wantLeft {
+		g.PanMap(west)
+		grid := g.MapWindow(5, 3)
+		if grid.MinX != want {
+			t.Errorf("pan %d: window starts at x %d, want %d", i+1, grid.MinX, want)
+		}
+		if !slices.Contains(grid.East, true) {
+			t.Errorf("pan %d: no arrow towards the player in the east", i+1)
+		}
+	}
+
+	g.CenterMap()
+	if grid := g.MapWindow(5, 3); grid.Cells[1][3] != CellPlayer {
+		t.Error("centring the map did not bring the player back into view")
+	}
+}
@@ -2,19 +2,92 @@ package main
 
 import (
 	"GentleWanderings/lib"
+	"GentleWanderings/lib/content"
+	"GentleWanderings/lib/export"
 	"GentleWanderings/lib/printer"
 	"bufio"
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// saveDir holds the named save slots, shared with the GUI.
+const saveDir = "./gui_game/saves"
+
 func main() {
-	game := lib.NewGame()
+	seed := flag.String("seed", "", "world seed to wander (a random world if omitted)")
+	reflections := flag.Bool("reflect", true, "offer a journaling prompt after each new place")
+	contentDir := flag.String("content", "", "directory of content packs to merge over the built-in themes and items")
+	ascii := flag.Bool("ascii", false, "draw maps with plain ASCII characters instead of emoji")
+	fullScreen := flag.Bool("tui", false, "play full screen, with the map, location and journal always in view")
+	undoDepth := flag.Int("undo", lib.DefaultHistory, "how many explorations undo can take back (0 turns it off)")
+	flag.Parse()
+
+	pack := content.Default()
+	if *contentDir != "" {
+		var err error
+		pack, err = content.Load(*contentDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "could not load content packs:\n%v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	worldSeed := time.Now().UnixNano()
+	if *seed != "" {
+		n, err := strconv.ParseInt(*seed, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid seed %q: seeds are whole numbers\n", *seed)
+			os.Exit(2)
+		}
+		worldSeed = n
+	}
+	game := lib.NewGameWithContent(worldSeed, pack)
+	game.SetHistory(*undoDepth)
 	scanner := bufio.NewScanner(os.Stdin)
+	term := printer.NewTerminal(scanner)
+	term.ASCII = *ascii
+	s := &session{
+		game:        game,
+		pack:        pack,
+		view:        term,
+		scanner:     scanner,
+		slots:       lib.NewSlots(saveDir),
+		reflections: *reflections,
+	}
+	s.commands = s.newCommands()
 
-	currentTile := game.GetTile(game.CurrentX, game.CurrentY)
+	if *fullScreen {
+		if err := term.RunTUI(game, *reflections); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	} else {
+		s.lineMode()
+	}
+
+	fmt.Println()
+	fmt.Println("╔════════════════════════════════════════════════════════════╗")
+	fmt.Println("║" + printer.CenterText("Journey Summary", 60) + "║")
+	fmt.Println("╚════════════════════════════════════════════════════════════╝")
+	fmt.Printf("\n🌱 World seed: %d\n", game.Seed())
+	fmt.Printf("🗓️  Days traveled: %d\n", game.TurnCount)
+	fmt.Printf("🗺️  Locations discovered: %d\n", len(game.Map))
+	fmt.Printf("🎒 Items collected: %d\n\n", len(game.Inventory))
+	fmt.Println("Thank you for wandering with us. Until next time... 🌙✨")
+	fmt.Println()
+}
+
+// lineMode plays the game a line at a time: it lists the ways out and runs
+// whatever command the player types, until they quit or input ends.
+func (s *session) lineMode() {
+	currentTile := s.game.CurrentTile()
 	fmt.Printf("🌿 %s\n", currentTile.Theme)
 	fmt.Printf("%s\n", currentTile.Description)
 	fmt.Printf("\n%s\n", currentTile.Discovery)
@@ -22,94 +95,143 @@ func main() {
 	for {
 		fmt.Println("\n" + strings.Repeat("─", 60))
 
-		// Show available directions
-		directions := game.GetAdjacentDirections()
-		if len(directions) == 0 {
-			fmt.Println("\nYou have explored all directions from here!")
-			fmt.Println("Commands: [menu] | [m]ap | [i]nventory | [j]ournal | [q]uit")
-		} else {
-			fmt.Println("\nWhere would you like to wander?")
-			for i, dir := range directions {
-				fmt.Printf("  %d. Explore %s\n", i+1, dir.Name)
-			}
-			fmt.Println("\nOther: [menu] | [m]ap | [i]nventory | [j]ournal | [q]uit")
+		// Show every way out, explored or not
+		fmt.Println("\nWhere would you like to wander?")
+		for i, exit := range s.game.GetExits() {
+			fmt.Printf("  %d. %s\n", i+1, exit.Label())
 		}
+		fmt.Println("\nOther: [menu] | [m]ap | [i]nventory | [j]ournal | [w]rite | travel <place> | export | undo | redo | save | load | replay | help | [q]uit")
 
 		fmt.Print("\n> ")
-		if !scanner.Scan() {
+		if !s.scanner.Scan() {
 			break
 		}
 
-		input := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" {
+			continue
+		}
+		// A number on its own picks a direction from the list above
+		if _, err := strconv.Atoi(strings.Fields(line)[0]); err == nil {
+			line = "go " + line
+		}
 
-		switch input {
-		case "menu":
-			game.ShowMenu(scanner)
-		case "m", "map":
-			game.ShowMap()
-		case "i", "inv", "inventory":
-			game.ShowInventory()
-		case "j", "journal":
-			game.ShowJournal()
-		case "q", "quit":
-			fmt.Println()
-			fmt.Println("╔════════════════════════════════════════════════════════════╗")
-			fmt.Println("║" + printer.CenterText("Journey Summary", 60) + "║")
-			fmt.Println("╚════════════════════════════════════════════════════════════╝")
-			fmt.Printf("\n🗓️  Days traveled: %d\n", game.TurnCount)
-			fmt.Printf("🗺️  Locations discovered: %d\n", len(game.Map))
-			fmt.Printf("🎒 Items collected: %d\n\n", len(game.Inventory))
-			fmt.Println("Thank you for wandering with us. Until next time... 🌙✨")
+		err := s.commands.Run(line)
+		if errors.Is(err, errQuit) {
+			break
+		}
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		// A journey saved with a card still to answer picks up there
+		if prompt := s.game.PendingPrompt(); prompt != nil {
 			fmt.Println()
-			return
+			s.answer(prompt)
+		}
+	}
+}
+
+// showFoundItem announces an item the player has just picked up.
+func showFoundItem(item *lib.Item) {
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("\n✨ You found something! ✨\n\n")
+	fmt.Printf("🎁 %s\n", item.Name)
+	fmt.Printf("   %s\n", item.Description)
+	fmt.Println()
+}
+
+// readEntry reads lines of journal text until an empty line.
+func readEntry(scanner *bufio.Scanner) string {
+	lines := []string{}
+	for {
+		fmt.Print("  ")
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mapCommand scrolls or zooms the map before it is shown: "map n/s/e/w" pans,
+// "map fit" shows the whole world and "map here" returns to the player.
+func mapCommand(game *lib.Game, arg string) error {
+	switch arg {
+	case "":
+		return nil
+	case "fit", "all":
+		game.FitMap()
+		return nil
+	case "here", "me":
+		game.CenterMap()
+		return nil
+	}
+	if dir, err := lib.DirectionNamed(arg); err == nil {
+		game.PanMap(dir)
+		return nil
+	}
+	return errors.New("usage: map [n|s|e|w|fit|here]")
+}
+
+// exportCommand handles "export journal [--format md|html|txt] [file]" and
+// "export map [file.png|file.svg]".
+func exportCommand(game *lib.Game, args []string) error {
+	usage := errors.New("usage: export journal [--format md|html|txt] [file] | export map [file.png|file.svg]")
+	if len(args) == 0 {
+		return usage
+	}
+
+	what, args := args[0], args[1:]
+	format := export.FormatMarkdown
+	path := ""
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--format" && i+1 < len(args):
+			format = strings.ToLower(args[i+1])
+			i++
+		case strings.HasPrefix(args[i], "--format="):
+			format = strings.ToLower(strings.TrimPrefix(args[i], "--format="))
+		case path == "":
+			path = args[i]
 		default:
-			// Try to parse as a direction number
-			choice, err := strconv.Atoi(input)
-			if err != nil || choice < 1 || choice > len(directions) {
-				fmt.Println("Invalid choice. Please try again.")
-				continue
-			}
-
-			selectedDir := directions[choice-1]
-
-			// Generate 3 location options
-			options := game.GenerateLocationOptions()
-
-			fmt.Printf("\n✨ As you head %s, three paths reveal themselves:\n\n", selectedDir.Name)
-			for i, opt := range options {
-				fmt.Printf("%d. %s\n   %s\n\n", i+1, opt.Theme, opt.Description)
-			}
-
-			fmt.Print("Which path calls to you? (1-3): ")
-			if !scanner.Scan() {
-				break
-			}
-
-			optChoice, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
-			if err != nil || optChoice < 1 || optChoice > 3 {
-				fmt.Println("Let's try that again...")
-				continue
-			}
-
-			selectedOption := options[optChoice-1]
-			foundItem := game.Explore(selectedDir, selectedOption)
-
-			newTile := game.GetTile(game.CurrentX, game.CurrentY)
-			fmt.Println()
-			fmt.Println("╔════════════════════════════════════════════════════════════╗")
-			fmt.Println("║" + printer.CenterText(newTile.Theme, 60) + "║")
-			fmt.Println("╚════════════════════════════════════════════════════════════╝")
-			fmt.Printf("\n%s\n", newTile.Description)
-			fmt.Printf("\n%s\n", newTile.Discovery)
-
-			if foundItem != nil {
-				fmt.Println()
-				fmt.Println(strings.Repeat("─", 60))
-				fmt.Printf("\n✨ You found something! ✨\n\n")
-				fmt.Printf("🎁 %s\n", foundItem.Name)
-				fmt.Printf("   %s\n", foundItem.Description)
-				fmt.Println()
-			}
+			return usage
+		}
+	}
+
+	switch strings.ToLower(what) {
+	case "journal":
+		if path == "" {
+			path = "journal." + format
+		}
+		var buf bytes.Buffer
+		if err := export.Journal(&buf, game, format); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("could not write %s: %w", path, err)
+		}
+		fmt.Printf("Your journal has been written to %s.\n", path)
+		return nil
+	case "map":
+		if path == "" {
+			path = "map.png"
+		}
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+		var buf bytes.Buffer
+		if err := export.Map(&buf, game, ext); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("could not write %s: %w", path, err)
 		}
+		fmt.Printf("Your map has been drawn to %s.\n", path)
+		return nil
 	}
+	return usage
 }